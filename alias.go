@@ -21,6 +21,10 @@ const (
 var (
 	// NewClientConfig gives an easy way for the callers to set client config
 	NewClientConfig = sdk.NewClientConfig
+	// NewTxQuery gives an easy way for the callers to build a query for tx searching
+	NewTxQuery = tendermint.NewTxQuery
+	// NewPayoutConfig gives an easy way for the callers to set the limits of a batch payout
+	NewPayoutConfig = token.NewPayoutConfig
 )
 
 // nolint
@@ -29,26 +33,64 @@ type (
 	// auth
 	Account = auth.Account
 	// staking
-	Validator     = staking.Validator
-	DelegatorResp = staking.DelegatorResp
+	Validator       = staking.Validator
+	BondStatus      = staking.BondStatus
+	JailStatus      = staking.JailStatus
+	ValidatorFilter = staking.ValidatorFilter
+	ValidatorView   = staking.ValidatorView
+	DelegatorResp   = staking.DelegatorResp
+	DelegateEvent   = staking.DelegateEvent
+	UnbondEvent     = staking.UnbondEvent
 	// token
 	Token             = token.Token
 	AccountTokensInfo = token.AccountTokensInfo
+	TransferEvent     = token.TransferEvent
+	PayoutRecord      = token.PayoutRecord
+	PayoutConfig      = token.PayoutConfig
+	TokenSupply       = token.TokenSupply
+	TokenMeta         = token.TokenMeta
+	BalanceEntry      = token.BalanceEntry
+	BalanceSheet      = token.BalanceSheet
 	// dex
-	TokenPair = dex.TokenPair
+	TokenPair    = dex.TokenPair
+	ListEvent    = dex.ListEvent
+	DepositEvent = dex.DepositEvent
+	DepositInfo  = dex.DepositInfo
+	DepositRank  = dex.DepositRank
+	WithdrawInfo = dex.WithdrawInfo
+	DexParams    = dex.Params
 	// order
-	BookRes     = order.BookRes
-	OrderDetail = order.OrderDetail
+	BookRes            = order.BookRes
+	OrderDetail        = order.OrderDetail
+	OrdersEvent        = order.OrdersEvent
+	FillEvent          = order.FillEvent
+	Side               = order.Side
+	OrderItem          = order.OrderItem
+	OrderResult        = order.OrderResult
+	OrderStatus        = order.OrderStatus
+	ProductRule        = order.ProductRule
+	CancelOrdersResult = order.CancelOrdersResult
+	ReplaceResult      = order.ReplaceResult
+	OrderParams        = order.Params
+	OrderEstimate      = order.OrderEstimate
 	// backend
-	Ticker      = backend.Ticker
-	MatchResult = backend.MatchResult
-	Order       = backend.Order
-	Deal        = backend.Deal
+	Ticker         = backend.Ticker
+	TickerDec      = backend.TickerDec
+	MatchResult    = backend.MatchResult
+	MatchResultDec = backend.MatchResultDec
+	Candle         = backend.Candle
+	Order          = backend.Order
+	Deal           = backend.Deal
+	Transaction    = backend.Transaction
+	ParamPage      = backend.ParamPage
+	ResponseError  = backend.ResponseError
 	// tendermint
 	Block            = tendermint.Block
 	BlockResults     = tendermint.BlockResults
+	BlockWithResults = tendermint.BlockWithResults
 	ResultCommit     = tendermint.ResultCommit
 	ResultValidators = tendermint.ResultValidators
 	ResultTx         = tendermint.ResultTx
 	ResultTxs        = tendermint.ResultTxs
+	TxQuery          = tendermint.TxQuery
 )
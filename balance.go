@@ -0,0 +1,93 @@
+package gosdk
+
+import (
+	"fmt"
+
+	"github.com/okex/okchain-go-sdk/module/token"
+	"github.com/okex/okchain-go-sdk/types/params"
+	"github.com/okex/okchain-go-sdk/utils"
+)
+
+// maxProductPages caps the pages of querying all the products, in case the paging is ignored by the node
+const maxProductPages = 100
+
+// QueryBalanceSheet merges the account coins, the tokens info and the amounts locked by all the open orders of an
+// address into one balance sheet, so that every locked amount on chain is compared with the open orders in full
+// NOTE: the open orders are only queried on the sides of the products that lock a denom with a locked amount on chain.
+// The orders locking a denom without any locked amount on chain are missed
+func (cli *Client) QueryBalanceSheet(addrStr string) (balanceSheet BalanceSheet, err error) {
+	acc, err := cli.Auth().QueryAccount(addrStr)
+	if err != nil {
+		return
+	}
+
+	accTokensInfo, err := cli.Token().QueryAccountTokensInfoDec(addrStr)
+	if err != nil {
+		return
+	}
+
+	lockedDenoms := make(map[string]bool)
+	for _, coinInfo := range accTokensInfo.Currencies {
+		if coinInfo.Locked.IsPositive() {
+			lockedDenoms[coinInfo.Symbol] = true
+		}
+	}
+
+	var tokenPairs []TokenPair
+	if len(lockedDenoms) != 0 {
+		if tokenPairs, err = cli.queryAllProducts(); err != nil {
+			return
+		}
+	}
+
+	var orders []Order
+	for _, tokenPair := range tokenPairs {
+		// a BUY order locks the quote asset while a SELL order locks the base asset
+		sideDenoms := [][2]string{{"BUY", tokenPair.QuoteAssetSymbol}, {"SELL", tokenPair.BaseAssetSymbol}}
+		for _, sideDenom := range sideDenoms {
+			if !lockedDenoms[sideDenom[1]] {
+				continue
+			}
+
+			productOrders, err := cli.Backend().QueryAllOpenOrders(addrStr, tokenPair.Product(), sideDenom[0], 0, 0, 0)
+			if err != nil {
+				return balanceSheet, err
+			}
+			orders = append(orders, productOrders...)
+		}
+	}
+
+	orderLocked, err := utils.GetOpenOrdersLockedCoins(orders)
+	if err != nil {
+		return
+	}
+
+	return token.NewBalanceSheet(accTokensInfo, acc.GetCoins(), orderLocked), nil
+}
+
+// queryAllProducts gets all the products listed on chain page by page. The paging stops once a page repeats the
+// previous one, which means the node returns the full list regardless of the page
+func (cli *Client) queryAllProducts() (tokenPairs []TokenPair, err error) {
+	var lastPage []TokenPair
+	for page := 1; ; page++ {
+		if page > maxProductPages {
+			return nil, fmt.Errorf("failed. products exceed %d pages", maxProductPages)
+		}
+
+		pageTokenPairs, err := cli.Dex().QueryProducts("", page, params.PerPageMax)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(pageTokenPairs) != 0 && len(lastPage) == len(pageTokenPairs) &&
+			lastPage[0].Product() == pageTokenPairs[0].Product() {
+			return tokenPairs, nil
+		}
+
+		tokenPairs = append(tokenPairs, pageTokenPairs...)
+		if len(pageTokenPairs) < params.PerPageMax {
+			return tokenPairs, nil
+		}
+		lastPage = pageTokenPairs
+	}
+}
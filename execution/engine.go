@@ -0,0 +1,236 @@
+package execution
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/okex/okchain-go-sdk/exposed"
+	ordertypes "github.com/okex/okchain-go-sdk/module/order/types"
+	sdk "github.com/okex/okchain-go-sdk/types"
+)
+
+// Engine executes the parent orders in child orders signed by one account. The child orders are placed and cancelled
+// in order through the account, so the broadcast mode of the client must be block to get the order IDs and keep the
+// sequence
+type Engine struct {
+	oc     exposed.Order
+	clock  Clock
+	config Config
+
+	mtx        sync.Mutex
+	account    Account
+	executions []*Execution
+	quit       chan struct{}
+}
+
+// NewEngine creates a new instance of Engine. It fails if the order client broadcasts in any mode other than block
+func NewEngine(oc exposed.Order, account Account, clock Clock, config Config) (*Engine, error) {
+	if client, ok := oc.(interface{ GetConfig() sdk.ClientConfig }); ok {
+		if broadcastMode := client.GetConfig().BroadcastMode; broadcastMode != sdk.BroadcastBlock {
+			return nil, fmt.Errorf("failed. broadcast mode %s is unsupported by the engine, which requires %s",
+				broadcastMode, sdk.BroadcastBlock)
+		}
+	}
+
+	if clock == nil {
+		clock = SystemClock()
+	}
+
+	return &Engine{
+		oc:      oc,
+		clock:   clock,
+		config:  config,
+		account: account,
+	}, nil
+}
+
+// Submit starts the execution of the parent order with the strategy. The child orders are priced by the pricer within
+// the limit price, or at the limit price if the pricer is nil
+func (e *Engine) Submit(parent ParentOrder, strategy Strategy, pricer Pricer) (*Execution, error) {
+	if e.account.Info == nil {
+		return nil, errors.New("failed. no key info of the account")
+	}
+
+	if err := parent.Validate(); err != nil {
+		return nil, err
+	}
+
+	if strategy == nil {
+		return nil, errors.New("failed. no strategy input")
+	}
+
+	if err := strategy.Validate(parent); err != nil {
+		return nil, err
+	}
+
+	rule, err := e.oc.QueryProductRule(parent.Product)
+	if err != nil {
+		return nil, err
+	}
+
+	if rule.Delisting {
+		return nil, fmt.Errorf("failed. product %s is delisting", parent.Product)
+	}
+
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	execution := &Execution{
+		engine:   e,
+		id:       len(e.executions) + 1,
+		parent:   parent,
+		strategy: strategy,
+		pricer:   pricer,
+		rule:     rule,
+		start:    e.clock.Now(),
+		filled:   sdk.ZeroDec(),
+		amount:   sdk.ZeroDec(),
+	}
+	e.executions = append(e.executions, execution)
+
+	return execution, nil
+}
+
+// Executions returns all the executions submitted to the engine in order
+func (e *Engine) Executions() []*Execution {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	return append([]*Execution(nil), e.executions...)
+}
+
+// Sequence returns the sequence of the next tx signed by the engine
+func (e *Engine) Sequence() uint64 {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	return e.account.SeqNum
+}
+
+// SetSequence resets the sequence of the next tx signed by the engine, such as after a tx broadcasted out of it
+func (e *Engine) SetSequence(seqNum uint64) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	e.account.SeqNum = seqNum
+}
+
+// Step checks the fills of the running executions, and places, cancels or reprices their child orders on the time of
+// the clock. All the running executions are stepped with their errors kept in their states, and the first error is
+// returned. An execution fails on a tx with an unknown outcome instead of placing the child order again
+func (e *Engine) Step() (err error) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	now := e.clock.Now()
+	for _, execution := range e.executions {
+		if execution.status != StatusRunning {
+			continue
+		}
+
+		execution.err = execution.fail(execution.step(now))
+		if execution.err != nil && err == nil {
+			err = fmt.Errorf("failed. execution %d: %s", execution.id, execution.err)
+		}
+	}
+
+	return
+}
+
+// Run steps the executions every interval of the config until Stop is called. The failed steps don't stop the loop,
+// whose errors are found in the states of the executions
+func (e *Engine) Run() error {
+	if e.config.Interval <= 0 {
+		return fmt.Errorf("failed. invalid interval %s", e.config.Interval)
+	}
+
+	e.mtx.Lock()
+	if e.quit != nil {
+		e.mtx.Unlock()
+		return errors.New("failed. engine is already running")
+	}
+	quit := make(chan struct{})
+	e.quit = quit
+	e.mtx.Unlock()
+
+	defer func() {
+		e.mtx.Lock()
+		e.quit = nil
+		e.mtx.Unlock()
+	}()
+
+	for {
+		select {
+		case <-quit:
+			return nil
+		case <-e.clock.After(e.config.Interval):
+			// the errors are kept in the states of the executions
+			_ = e.Step()
+		}
+	}
+}
+
+// Stop stops the running loop. The live child orders are left on the book
+func (e *Engine) Stop() {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	if e.quit != nil {
+		close(e.quit)
+		e.quit = nil
+	}
+}
+
+// placeOrder places the child order and returns its ID. The sequence is taken once the tx is broadcasted, and the
+// outcome is unknown if the tx is broadcasted with an error or without the order result
+func (e *Engine) placeOrder(orderItem ordertypes.OrderItem) (string, error) {
+	resp, results, err := e.oc.NewOrdersWithItems(e.account.Info, e.account.PassWd, []ordertypes.OrderItem{orderItem},
+		e.config.Memo, e.account.AccNum, e.account.SeqNum)
+	if len(resp.TxHash) != 0 {
+		e.account.SeqNum++
+	}
+
+	switch {
+	case resp.Code != 0:
+		return "", fmt.Errorf("failed. new order tx %s failed with code %d: %s", resp.TxHash, resp.Code, resp.RawLog)
+	case err != nil && len(resp.TxHash) == 0:
+		return "", err
+	case err != nil:
+		return "", unknownOutcomeError{txHash: resp.TxHash, reason: err.Error()}
+	case len(results) != 1:
+		return "", unknownOutcomeError{txHash: resp.TxHash, reason: "no order result"}
+	}
+
+	if results[0].Code != 0 {
+		return "", fmt.Errorf("failed. new order rejected with code %d: %s", results[0].Code, results[0].Message)
+	}
+
+	return results[0].OrderID, nil
+}
+
+// cancelOrder cancels the child order. The sequence is taken once the tx is broadcasted, and the outcome is unknown if
+// the tx is broadcasted with an error
+func (e *Engine) cancelOrder(orderID string) error {
+	resp, _, err := e.oc.CancelOrdersWithIDs(e.account.Info, e.account.PassWd, []string{orderID}, e.config.Memo,
+		e.account.AccNum, e.account.SeqNum)
+	if len(resp.TxHash) != 0 {
+		e.account.SeqNum++
+	}
+
+	switch {
+	case resp.Code != 0:
+		return fmt.Errorf("failed. cancel order tx %s failed with code %d: %s", resp.TxHash, resp.Code, resp.RawLog)
+	case err != nil && len(resp.TxHash) == 0:
+		return err
+	case err != nil:
+		return unknownOutcomeError{txHash: resp.TxHash, reason: err.Error()}
+	}
+
+	// a rejected cancellation of a child already closed on chain is found by the following detail query
+	return nil
+}
+
+// unknownOutcomeError is the error of a tx broadcasted without knowing whether its order is placed or cancelled
+type unknownOutcomeError struct {
+	txHash string
+	reason string
+}
+
+func (uoe unknownOutcomeError) Error() string {
+	return fmt.Sprintf("failed. unknown outcome of tx %s: %s", uoe.txHash, uoe.reason)
+}
@@ -0,0 +1,329 @@
+package execution
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/okex/okchain-go-sdk/mocks"
+	dextypes "github.com/okex/okchain-go-sdk/module/dex/types"
+	"github.com/okex/okchain-go-sdk/module/order"
+	ordertypes "github.com/okex/okchain-go-sdk/module/order/types"
+	sdk "github.com/okex/okchain-go-sdk/types"
+	"github.com/okex/okchain-go-sdk/utils"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	addr     = "okchain1dcsxvxgj374dv3wt9szflf9nz6342juzzkjnlz"
+	name     = "alice"
+	passWd   = "12345678"
+	mnemonic = "dumb thought reward exhibit quick manage force imitate blossom vendor ketchup sniff"
+
+	product = "btc-000_okt"
+	accNum  = uint64(1)
+)
+
+type fakeClock struct {
+	now time.Time
+	// onAfter is called on each wait for the next step
+	onAfter func()
+}
+
+func (fc *fakeClock) Now() time.Time {
+	return fc.now
+}
+
+func (fc *fakeClock) After(d time.Duration) <-chan time.Time {
+	if fc.onAfter != nil {
+		fc.onAfter()
+	}
+
+	fc.now = fc.now.Add(d)
+	ch := make(chan time.Time, 1)
+	ch <- fc.now
+	return ch
+}
+
+func (fc *fakeClock) Advance(d time.Duration) {
+	fc.now = fc.now.Add(d)
+}
+
+func newMockClient(t *testing.T, ctrl *gomock.Controller) mocks.MockClient {
+	config, err := sdk.NewClientConfig("testURL", "testChain", sdk.BroadcastBlock, "0.01okt", 200000)
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(order.NewOrderClient(mockCli.MockBaseClient))
+
+	tokenPairs := []dextypes.TokenPair{
+		{BaseAssetSymbol: "btc-000", QuoteAssetSymbol: "okt", MaxPriceDigit: 4, MaxQuantityDigit: 4,
+			MinQuantity: sdk.MustNewDecFromStr("0.001")},
+	}
+	expectedCdc := mockCli.GetCodec()
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).AnyTimes()
+	mockCli.EXPECT().GetConfig().Return(config).AnyTimes()
+	mockCli.EXPECT().Query(dextypes.ProductsPath, gomock.Any()).Return(expectedCdc.MustMarshalJSON(tokenPairs), nil)
+	return mockCli
+}
+
+func expectNewOrder(t *testing.T, mockCli mocks.MockClient, seqNum uint64, orderID, price, quantity string) {
+	mockCli.EXPECT().BuildAndBroadcast(name, passWd, "", gomock.Any(), accNum, seqNum).DoAndReturn(
+		func(_, _, _ string, msgs []sdk.Msg, _, _ uint64) (sdk.TxResponse, error) {
+			orderItems := msgs[0].(ordertypes.MsgNewOrders).OrderItems
+			require.Equal(t, 1, len(orderItems))
+			require.Equal(t, sdk.MustNewDecFromStr(price).String(), orderItems[0].Price.String())
+			require.Equal(t, sdk.MustNewDecFromStr(quantity).String(), orderItems[0].Quantity.String())
+			return mocks.MockOrdersTxResponse(ordertypes.ActionNewOrders, addr,
+				[]ordertypes.OrderResult{{OrderID: orderID}}), nil
+		})
+}
+
+func expectCancelOrder(mockCli mocks.MockClient, seqNum uint64, orderID string) {
+	mockCli.EXPECT().BuildAndBroadcast(name, passWd, "", gomock.Any(), accNum, seqNum).Return(
+		mocks.MockOrdersTxResponse(ordertypes.ActionCancelOrders, addr, []ordertypes.OrderResult{{OrderID: orderID}}),
+		nil)
+}
+
+func expectOrderDetail(mockCli mocks.MockClient, orderID, side string, status ordertypes.OrderStatus, price,
+	quantity, remain string) {
+	sender, err := sdk.AccAddressFromBech32(addr)
+	if err != nil {
+		panic(err)
+	}
+
+	detailBytes := mockCli.BuildOrderDetailBytes("", orderID, "", product, side, int64(status), 0, 0, sender,
+		sdk.MustNewDecFromStr(price), sdk.MustNewDecFromStr(quantity), sdk.MustNewDecFromStr(price),
+		sdk.MustNewDecFromStr(remain), sdk.ZeroDec(), sdk.NewDecCoinFromDec("okt", sdk.ZeroDec()))
+	mockCli.EXPECT().Query(fmt.Sprintf("%s/%s", ordertypes.OrderDetailPath, orderID), nil).Return(detailBytes, nil)
+}
+
+func TestEngine_TWAP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCli := newMockClient(t, ctrl)
+	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
+	require.NoError(t, err)
+
+	clock := &fakeClock{now: time.Unix(1600000000, 0)}
+	engine, err := NewEngine(mockCli.Order(), Account{Info: fromInfo, PassWd: passWd, AccNum: accNum, SeqNum: 2}, clock,
+		DefaultConfig())
+	require.NoError(t, err)
+	parent := ParentOrder{Product: product, Side: ordertypes.SideBuy, Price: sdk.MustNewDecFromStr("2"),
+		Quantity: sdk.MustNewDecFromStr("10")}
+	_, err = engine.Submit(parent, TWAP{Duration: 50 * time.Second}, nil)
+	require.Error(t, err)
+	execution, err := engine.Submit(parent, TWAP{Duration: 50 * time.Second, Slices: 5}, nil)
+	require.NoError(t, err)
+
+	// slice 1 places the first child
+	expectNewOrder(t, mockCli, 2, "ID1", "2", "2")
+	require.NoError(t, engine.Step())
+	require.Equal(t, "ID1", execution.State().ChildOrderID)
+
+	// slice 2 places the next child after the first one filled
+	clock.Advance(10 * time.Second)
+	expectOrderDetail(mockCli, "ID1", "BUY", ordertypes.OrderStatusFilled, "2", "2", "0")
+	expectNewOrder(t, mockCli, 3, "ID2", "2", "2")
+	require.NoError(t, engine.Step())
+
+	// slice 3 carries the unfilled quantity of the partially filled child into a new one
+	clock.Advance(10 * time.Second)
+	expectOrderDetail(mockCli, "ID2", "BUY", ordertypes.OrderStatusOpen, "2", "2", "1.5")
+	expectCancelOrder(mockCli, 4, "ID2")
+	expectOrderDetail(mockCli, "ID2", "BUY", ordertypes.OrderStatusPartialFilledCancelled, "2", "2", "1.5")
+	expectNewOrder(t, mockCli, 5, "ID3", "2", "3.5")
+	require.NoError(t, engine.Step())
+
+	state := execution.State()
+	require.Equal(t, StatusRunning, state.Status)
+	require.Equal(t, sdk.MustNewDecFromStr("2.5"), state.Filled)
+	require.Equal(t, sdk.MustNewDecFromStr("2"), state.AvgPrice)
+	require.Equal(t, []string{"ID1", "ID2", "ID3"}, state.Children)
+
+	// pausing cancels the live child, and a paused execution isn't stepped
+	expectCancelOrder(mockCli, 6, "ID3")
+	expectOrderDetail(mockCli, "ID3", "BUY", ordertypes.OrderStatusCancelled, "2", "3.5", "3.5")
+	require.NoError(t, execution.Pause())
+	require.Error(t, execution.Pause())
+	require.NoError(t, engine.Step())
+	require.Equal(t, StatusPaused, execution.State().Status)
+	require.Empty(t, execution.State().ChildOrderID)
+
+	// the missed slices are carried into the child after resuming
+	require.NoError(t, execution.Resume())
+	require.Error(t, execution.Resume())
+	clock.Advance(time.Minute)
+	expectNewOrder(t, mockCli, 7, "ID4", "2", "7.5")
+	require.NoError(t, engine.Step())
+
+	expectCancelOrder(mockCli, 8, "ID4")
+	expectOrderDetail(mockCli, "ID4", "BUY", ordertypes.OrderStatusPartialFilledCancelled, "2", "7.5", "0.5")
+	require.NoError(t, execution.Cancel())
+	require.Error(t, execution.Cancel())
+	state = execution.State()
+	require.Equal(t, StatusCancelled, state.Status)
+	require.Equal(t, sdk.MustNewDecFromStr("9.5"), state.Filled)
+	require.Equal(t, uint64(9), engine.Sequence())
+}
+
+func TestEngine_TWAPRounding(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCli := newMockClient(t, ctrl)
+	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
+	require.NoError(t, err)
+
+	clock := &fakeClock{now: time.Unix(1600000000, 0)}
+	engine, err := NewEngine(mockCli.Order(), Account{Info: fromInfo, PassWd: passWd, AccNum: accNum, SeqNum: 2}, clock,
+		DefaultConfig())
+	require.NoError(t, err)
+	// the slices of 10 over 3 exceed the quantity digits
+	parent := ParentOrder{Product: product, Side: ordertypes.SideBuy, Price: sdk.MustNewDecFromStr("2"),
+		Quantity: sdk.MustNewDecFromStr("10")}
+	execution, err := engine.Submit(parent, TWAP{Duration: 30 * time.Second, Slices: 3}, nil)
+	require.NoError(t, err)
+
+	expectNewOrder(t, mockCli, 2, "ID1", "2", "3.3333")
+	require.NoError(t, engine.Step())
+
+	// the truncated child is kept within the slice
+	clock.Advance(5 * time.Second)
+	expectOrderDetail(mockCli, "ID1", "BUY", ordertypes.OrderStatusOpen, "2", "3.3333", "3.3333")
+	require.NoError(t, engine.Step())
+	require.Equal(t, []string{"ID1"}, execution.State().Children)
+
+	clock.Advance(5 * time.Second)
+	expectOrderDetail(mockCli, "ID1", "BUY", ordertypes.OrderStatusFilled, "2", "3.3333", "0")
+	expectNewOrder(t, mockCli, 3, "ID2", "2", "3.3333")
+	require.NoError(t, engine.Step())
+
+	clock.Advance(10 * time.Second)
+	expectOrderDetail(mockCli, "ID2", "BUY", ordertypes.OrderStatusFilled, "2", "3.3333", "0")
+	expectNewOrder(t, mockCli, 4, "ID3", "2", "3.3334")
+	require.NoError(t, engine.Step())
+	require.Equal(t, uint64(5), engine.Sequence())
+}
+
+func TestEngine_Iceberg(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCli := newMockClient(t, ctrl)
+	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
+	require.NoError(t, err)
+
+	clock := &fakeClock{now: time.Unix(1600000000, 0)}
+	engine, err := NewEngine(mockCli.Order(), Account{Info: fromInfo, PassWd: passWd, AccNum: accNum}, clock,
+		DefaultConfig())
+	require.NoError(t, err)
+	prices := []string{"1.23456", "0.9", "1.1", "1.1"}
+	pricer := func(ParentOrder) (sdk.Dec, error) {
+		if len(prices) == 0 {
+			return sdk.Dec{}, errors.New("no price")
+		}
+
+		price := sdk.MustNewDecFromStr(prices[0])
+		prices = prices[1:]
+		return price, nil
+	}
+	parent := ParentOrder{Product: product, Side: ordertypes.SideSell, Price: sdk.OneDec(),
+		Quantity: sdk.MustNewDecFromStr("3")}
+	_, err = engine.Submit(parent, Iceberg{}, pricer)
+	require.Error(t, err)
+	execution, err := engine.Submit(parent, Iceberg{VisibleQuantity: sdk.MustNewDecFromStr("2")}, pricer)
+	require.NoError(t, err)
+
+	// the price is rounded to the price digits of the product
+	expectNewOrder(t, mockCli, 0, "ID1", "1.2345", "2")
+	require.NoError(t, engine.Step())
+
+	// the unfilled child is repriced within the limit price
+	expectOrderDetail(mockCli, "ID1", "SELL", ordertypes.OrderStatusOpen, "1.2345", "2", "2")
+	expectCancelOrder(mockCli, 1, "ID1")
+	expectOrderDetail(mockCli, "ID1", "SELL", ordertypes.OrderStatusCancelled, "1.2345", "2", "2")
+	expectNewOrder(t, mockCli, 2, "ID2", "1", "2")
+	require.NoError(t, engine.Step())
+
+	// the partially filled child is repriced with its unfilled quantity
+	expectOrderDetail(mockCli, "ID2", "SELL", ordertypes.OrderStatusOpen, "1", "2", "0.5")
+	expectCancelOrder(mockCli, 3, "ID2")
+	expectOrderDetail(mockCli, "ID2", "SELL", ordertypes.OrderStatusPartialFilledCancelled, "1", "2", "0.5")
+	expectNewOrder(t, mockCli, 4, "ID3", "1.1", "1.5")
+	require.NoError(t, engine.Step())
+
+	// the partially filled child keeps resting without a price change
+	expectOrderDetail(mockCli, "ID3", "SELL", ordertypes.OrderStatusOpen, "1.1", "1.5", "1")
+	require.NoError(t, engine.Step())
+
+	// the execution is done once the parent is filled
+	expectOrderDetail(mockCli, "ID3", "SELL", ordertypes.OrderStatusFilled, "1.1", "1.5", "0")
+	require.NoError(t, engine.Step())
+	state := execution.State()
+	require.Equal(t, StatusDone, state.Status)
+	require.Equal(t, sdk.MustNewDecFromStr("3"), state.Filled)
+	// (1*1.5 + 1.1*1.5) / 3
+	require.Equal(t, sdk.MustNewDecFromStr("1.05"), state.AvgPrice)
+	require.Error(t, execution.Pause())
+
+	// the failed steps are kept in the state without stopping Run
+	execution, err = engine.Submit(parent, Iceberg{VisibleQuantity: sdk.MustNewDecFromStr("2")}, pricer)
+	require.NoError(t, err)
+	steps := 0
+	clock.onAfter = func() {
+		if steps++; steps == 3 {
+			engine.Stop()
+		}
+	}
+	require.NoError(t, engine.Run())
+	require.Equal(t, StatusRunning, execution.State().Status)
+	require.Equal(t, "no price", execution.State().Error)
+
+	idleEngine, err := NewEngine(mockCli.Order(), Account{}, clock, Config{})
+	require.NoError(t, err)
+	require.Error(t, idleEngine.Run())
+}
+
+func TestEngine_UnknownOutcome(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCli := newMockClient(t, ctrl)
+	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
+	require.NoError(t, err)
+
+	clock := &fakeClock{now: time.Unix(1600000000, 0)}
+	engine, err := NewEngine(mockCli.Order(), Account{Info: fromInfo, PassWd: passWd, AccNum: accNum}, clock,
+		DefaultConfig())
+	require.NoError(t, err)
+	parent := ParentOrder{Product: product, Side: ordertypes.SideBuy, Price: sdk.MustNewDecFromStr("2"),
+		Quantity: sdk.MustNewDecFromStr("10")}
+	execution, err := engine.Submit(parent, Iceberg{VisibleQuantity: sdk.MustNewDecFromStr("2")}, nil)
+	require.NoError(t, err)
+
+	// the tx unsent is tried again in the next step
+	mockCli.EXPECT().BuildAndBroadcast(name, passWd, "", gomock.Any(), accNum, uint64(0)).
+		Return(sdk.TxResponse{}, errors.New("connection refused"))
+	require.Error(t, engine.Step())
+	require.Equal(t, StatusRunning, execution.State().Status)
+	require.Equal(t, uint64(0), engine.Sequence())
+
+	// the tx broadcasted with an error takes the sequence and fails the execution instead of placing the child again
+	mockCli.EXPECT().BuildAndBroadcast(name, passWd, "", gomock.Any(), accNum, uint64(0)).
+		Return(sdk.TxResponse{TxHash: "tx hash"}, errors.New("timed out waiting for tx to be included"))
+	require.Error(t, engine.Step())
+	state := execution.State()
+	require.Equal(t, StatusFailed, state.Status)
+	require.Contains(t, state.Error, "tx hash")
+	require.Equal(t, uint64(1), engine.Sequence())
+	require.NoError(t, engine.Step())
+	require.Error(t, execution.Cancel())
+
+	// the client broadcasting in any mode other than block is refused
+	config, err := sdk.NewClientConfig("testURL", "testChain", sdk.BroadcastSync, "0.01okt", 200000)
+	require.NoError(t, err)
+	syncCli := mocks.NewMockClient(t, ctrl, config)
+	syncCli.RegisterModule(order.NewOrderClient(syncCli.MockBaseClient))
+	syncCli.EXPECT().GetConfig().Return(config)
+	_, err = NewEngine(syncCli.Order(), Account{Info: fromInfo}, clock, DefaultConfig())
+	require.Error(t, err)
+}
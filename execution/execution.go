@@ -0,0 +1,282 @@
+package execution
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	ordertypes "github.com/okex/okchain-go-sdk/module/order/types"
+	sdk "github.com/okex/okchain-go-sdk/types"
+)
+
+// childOrder - structure of the live child order of an execution
+type childOrder struct {
+	orderID  string
+	price    sdk.Dec
+	quantity sdk.Dec
+	// filled and filledAmount are the quantity and the quote amount accounted into the execution
+	filled       sdk.Dec
+	filledAmount sdk.Dec
+}
+
+func (co *childOrder) remain() sdk.Dec {
+	return co.quantity.Sub(co.filled)
+}
+
+// Execution - structure of the execution of a parent order. It's stepped and guarded by its engine
+type Execution struct {
+	engine   *Engine
+	id       int
+	parent   ParentOrder
+	strategy Strategy
+	pricer   Pricer
+	rule     ordertypes.ProductRule
+	start    time.Time
+
+	status   Status
+	err      error
+	filled   sdk.Dec
+	amount   sdk.Dec
+	child    *childOrder
+	children []string
+}
+
+// ID returns the ID of the execution in its engine
+func (x *Execution) ID() int {
+	return x.id
+}
+
+// State returns the snapshot of the execution
+func (x *Execution) State() State {
+	x.engine.mtx.Lock()
+	defer x.engine.mtx.Unlock()
+	state := State{
+		ID:       x.id,
+		Parent:   x.parent,
+		Status:   x.status,
+		Filled:   x.filled,
+		AvgPrice: sdk.ZeroDec(),
+		Children: append([]string(nil), x.children...),
+	}
+	if x.filled.IsPositive() {
+		state.AvgPrice = x.amount.Quo(x.filled)
+	}
+	if x.child != nil {
+		state.ChildOrderID = x.child.orderID
+	}
+	if x.err != nil {
+		state.Error = x.err.Error()
+	}
+
+	return state
+}
+
+// Pause cancels the live child order and stops placing new ones until Resume is called. The TWAP schedule keeps
+// running while paused, so the missed slices are carried into the child order after resuming
+func (x *Execution) Pause() error {
+	x.engine.mtx.Lock()
+	defer x.engine.mtx.Unlock()
+	if x.status != StatusRunning {
+		return fmt.Errorf("failed. execution %d is %s", x.id, x.status)
+	}
+
+	if err := x.cancelChild(); err != nil {
+		return x.fail(err)
+	}
+
+	x.status = StatusPaused
+	return nil
+}
+
+// Resume restarts placing child orders of the paused execution from the next step
+func (x *Execution) Resume() error {
+	x.engine.mtx.Lock()
+	defer x.engine.mtx.Unlock()
+	if x.status != StatusPaused {
+		return fmt.Errorf("failed. execution %d is %s", x.id, x.status)
+	}
+
+	x.status = StatusRunning
+	return nil
+}
+
+// Cancel cancels the live child order and ends the execution with the filled quantity
+func (x *Execution) Cancel() error {
+	x.engine.mtx.Lock()
+	defer x.engine.mtx.Unlock()
+	if x.status != StatusRunning && x.status != StatusPaused {
+		return fmt.Errorf("failed. execution %d is %s", x.id, x.status)
+	}
+
+	if err := x.cancelChild(); err != nil {
+		return x.fail(err)
+	}
+
+	x.status = StatusCancelled
+	return nil
+}
+
+// fail ends the execution on the error of a tx with an unknown outcome, since a child order may be left live or the
+// sequence may be taken. It returns the error input
+func (x *Execution) fail(err error) error {
+	if _, ok := err.(unknownOutcomeError); ok {
+		x.status = StatusFailed
+		x.err = err
+	}
+
+	return err
+}
+
+func (x *Execution) step(now time.Time) error {
+	if err := x.refreshChild(); err != nil {
+		return err
+	}
+
+	rest := x.parent.Quantity.Sub(x.filled)
+	// the rest below the min quantity can't be placed any more
+	if !rest.IsPositive() || (x.child == nil && !x.rule.MinQuantity.IsNil() && rest.LT(x.rule.MinQuantity)) {
+		x.status = StatusDone
+		return nil
+	}
+
+	price, err := x.price()
+	if err != nil {
+		return err
+	}
+
+	childRemain := sdk.ZeroDec()
+	if x.child != nil {
+		childRemain = x.child.remain()
+	}
+
+	quantity := x.childQuantity(now, childRemain)
+	if x.child != nil {
+		if x.child.price.Equal(price) && childRemain.Equal(quantity) {
+			return nil
+		}
+
+		// reprice or resize the live child with the unfilled quantity
+		if err = x.cancelChild(); err != nil {
+			return err
+		}
+
+		quantity = x.childQuantity(now, sdk.ZeroDec())
+	}
+
+	if !quantity.IsPositive() {
+		return nil
+	}
+
+	orderItem, err := x.rule.Round(ordertypes.NewOrderItemDec(x.parent.Product, x.parent.Side, price, quantity))
+	if err != nil {
+		// wait for a larger child in the following slices
+		if orderItem.Quantity.LT(x.parent.Quantity.Sub(x.filled)) {
+			return nil
+		}
+
+		return err
+	}
+
+	orderID, err := x.engine.placeOrder(orderItem)
+	if err != nil {
+		return err
+	}
+
+	x.child = &childOrder{
+		orderID:      orderID,
+		price:        orderItem.Price,
+		quantity:     orderItem.Quantity,
+		filled:       sdk.ZeroDec(),
+		filledAmount: sdk.ZeroDec(),
+	}
+	x.children = append(x.children, orderID)
+	return nil
+}
+
+// childQuantity returns the quantity of the child decided by the strategy within the unfilled quantity. It's truncated
+// to the quantity digits of the product as the child is placed with, so that it's comparable with the live child
+func (x *Execution) childQuantity(now time.Time, childRemain sdk.Dec) sdk.Dec {
+	quantity := x.strategy.ChildQuantity(x.parent, x.start, now, x.filled, childRemain)
+	if rest := x.parent.Quantity.Sub(x.filled); quantity.GT(rest) {
+		quantity = rest
+	}
+
+	// only the quantity digits matter here
+	orderItem, _ := x.rule.Round(ordertypes.NewOrderItemDec(x.parent.Product, x.parent.Side, x.parent.Price,
+		quantity))
+	return orderItem.Quantity
+}
+
+// price returns the price of the next child within the limit price
+func (x *Execution) price() (sdk.Dec, error) {
+	if x.pricer == nil {
+		return x.parent.Price, nil
+	}
+
+	price, err := x.pricer(x.parent)
+	if err != nil {
+		return price, err
+	}
+
+	if price.IsNil() || !price.IsPositive() {
+		return price, errors.New("failed. price of the pricer must be positive")
+	}
+
+	price = x.parent.capPrice(price)
+	orderItem, err := x.rule.Round(ordertypes.NewOrderItemDec(x.parent.Product, x.parent.Side, price,
+		x.parent.Quantity))
+	if err != nil && orderItem.Price.IsPositive() {
+		// only the price digits matter here
+		err = nil
+	}
+
+	return orderItem.Price, err
+}
+
+// refreshChild accounts the fills of the live child through its detail, and drops it once closed
+func (x *Execution) refreshChild() error {
+	if x.child == nil {
+		return nil
+	}
+
+	orderDetail, err := x.engine.oc.QueryOrderDetail(x.child.orderID)
+	if err != nil {
+		return err
+	}
+
+	filled := orderDetail.Quantity.Sub(orderDetail.RemainQuantity)
+	filledAmount := sdk.ZeroDec()
+	if !orderDetail.FilledAvgPrice.IsNil() {
+		filledAmount = orderDetail.FilledAvgPrice.Mul(filled)
+	}
+
+	x.filled = x.filled.Add(filled.Sub(x.child.filled))
+	x.amount = x.amount.Add(filledAmount.Sub(x.child.filledAmount))
+	x.child.filled, x.child.filledAmount = filled, filledAmount
+	if orderDetail.GetStatus().IsFinal() {
+		x.child = nil
+	}
+
+	return nil
+}
+
+// cancelChild cancels the live child and accounts its last fills
+func (x *Execution) cancelChild() error {
+	if x.child == nil {
+		return nil
+	}
+
+	if err := x.engine.cancelOrder(x.child.orderID); err != nil {
+		return err
+	}
+
+	if err := x.refreshChild(); err != nil {
+		return err
+	}
+
+	if x.child != nil {
+		return fmt.Errorf("failed. child order %s is still open after the cancellation", x.child.orderID)
+	}
+
+	return nil
+}
@@ -0,0 +1,203 @@
+package execution
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	ordertypes "github.com/okex/okchain-go-sdk/module/order/types"
+	sdk "github.com/okex/okchain-go-sdk/types"
+	"github.com/okex/okchain-go-sdk/types/crypto/keys"
+)
+
+// Clock tells the time to the engine, so that the schedule can be driven by a fake clock in tests
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+// SystemClock returns the clock of the system time
+func SystemClock() Clock {
+	return systemClock{}
+}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// Status shows the state of an execution
+type Status int
+
+// const of the execution status
+const (
+	StatusRunning Status = iota
+	StatusPaused
+	StatusDone
+	StatusCancelled
+	StatusFailed
+)
+
+// String returns the name of the status
+func (s Status) String() string {
+	switch s {
+	case StatusRunning:
+		return "Running"
+	case StatusPaused:
+		return "Paused"
+	case StatusDone:
+		return "Done"
+	case StatusCancelled:
+		return "Cancelled"
+	case StatusFailed:
+		return "Failed"
+	default:
+		return fmt.Sprintf("Unknown(%d)", int(s))
+	}
+}
+
+// Account - structure of the signer of the child orders. SeqNum increases with each tx broadcasted by the engine
+type Account struct {
+	Info   keys.Info
+	PassWd string
+	AccNum uint64
+	SeqNum uint64
+}
+
+// ParentOrder - structure of the order to execute in child orders. Price is the limit price, which no child order is
+// placed beyond
+type ParentOrder struct {
+	Product  string          `json:"product"`
+	Side     ordertypes.Side `json:"side"`
+	Price    sdk.Dec         `json:"price"`
+	Quantity sdk.Dec         `json:"quantity"`
+}
+
+// Validate gives a quick validity check for the parent order
+func (po ParentOrder) Validate() error {
+	return ordertypes.NewOrderItemDec(po.Product, po.Side, po.Price, po.Quantity).Validate()
+}
+
+// capPrice keeps the price within the limit price
+func (po ParentOrder) capPrice(price sdk.Dec) sdk.Dec {
+	if (po.Side == ordertypes.SideBuy && price.GT(po.Price)) || (po.Side == ordertypes.SideSell && price.LT(po.Price)) {
+		return po.Price
+	}
+
+	return price
+}
+
+// Pricer gives the price of the next child order, such as the best bid or ask of the local order book
+type Pricer func(parent ParentOrder) (sdk.Dec, error)
+
+// Strategy decides the quantity of the child order resting on the book over time
+type Strategy interface {
+	Validate(parent ParentOrder) error
+	// ChildQuantity returns the quantity of the child order that should rest on the book at the time. The live child
+	// is replaced if it differs from the remaining quantity of the child, which is zero without a live one
+	ChildQuantity(parent ParentOrder, start, now time.Time, filled, childRemain sdk.Dec) sdk.Dec
+}
+
+// TWAP slices the parent order into equal child orders over the duration. At the start of each slice, the unfilled
+// quantity of the previous slices is carried into the new child order
+type TWAP struct {
+	Duration time.Duration
+	Slices   int64
+}
+
+// Validate gives a quick validity check for the TWAP strategy
+func (twap TWAP) Validate(ParentOrder) error {
+	if twap.Duration <= 0 {
+		return errors.New("failed. duration of TWAP must be positive")
+	}
+
+	if twap.Slices <= 0 {
+		return errors.New("failed. slices of TWAP must be positive")
+	}
+
+	return nil
+}
+
+// ChildQuantity returns the scheduled quantity by the current slice minus the filled one
+func (twap TWAP) ChildQuantity(parent ParentOrder, start, now time.Time, filled, _ sdk.Dec) sdk.Dec {
+	slice := int64(now.Sub(start) / (twap.Duration / time.Duration(twap.Slices)))
+	if slice >= twap.Slices {
+		slice = twap.Slices - 1
+	}
+
+	scheduled := parent.Quantity.MulInt64(slice + 1).QuoInt64(twap.Slices)
+	return positiveOrZero(scheduled.Sub(filled))
+}
+
+// Iceberg shows only the visible quantity of the parent order on the book. The next child order is placed once the
+// previous one is filled
+type Iceberg struct {
+	VisibleQuantity sdk.Dec
+}
+
+// Validate gives a quick validity check for the iceberg strategy
+func (iceberg Iceberg) Validate(ParentOrder) error {
+	if iceberg.VisibleQuantity.IsNil() || !iceberg.VisibleQuantity.IsPositive() {
+		return errors.New("failed. visible quantity of iceberg must be positive")
+	}
+
+	return nil
+}
+
+// ChildQuantity keeps the live child until it's filled, or returns the visible quantity within the unfilled one
+func (iceberg Iceberg) ChildQuantity(parent ParentOrder, _, _ time.Time, filled, childRemain sdk.Dec) sdk.Dec {
+	if childRemain.IsPositive() {
+		return childRemain
+	}
+
+	rest := positiveOrZero(parent.Quantity.Sub(filled))
+	if rest.GT(iceberg.VisibleQuantity) {
+		return iceberg.VisibleQuantity
+	}
+
+	return rest
+}
+
+// Config - structure of the config for Engine
+type Config struct {
+	// Interval is the interval to step the executions in Run
+	Interval time.Duration
+	// Memo is the memo of the txs broadcasted by the engine
+	Memo string
+}
+
+// DefaultConfig returns the default config of Engine
+func DefaultConfig() Config {
+	return Config{
+		Interval: 3 * time.Second,
+	}
+}
+
+// State - structure of the snapshot of an execution
+type State struct {
+	ID     int         `json:"id"`
+	Parent ParentOrder `json:"parent"`
+	Status Status      `json:"status"`
+	Filled sdk.Dec     `json:"filled"`
+	// AvgPrice is the average fill price, which is zero before any fill
+	AvgPrice sdk.Dec `json:"avg_price"`
+	// ChildOrderID is the ID of the live child order, or empty without one
+	ChildOrderID string `json:"child_order_id"`
+	// Children is the IDs of all the child orders placed in order
+	Children []string `json:"children"`
+	// Error is the error of the last step, or the one that failed the execution
+	Error string `json:"error"`
+}
+
+func positiveOrZero(dec sdk.Dec) sdk.Dec {
+	if dec.IsPositive() {
+		return dec
+	}
+
+	return sdk.ZeroDec()
+}
@@ -20,4 +20,35 @@ type BackendQuery interface {
 	QueryClosedOrders(addrStr, product, side string, start, end, page, perPage int) ([]types.Order, error)
 	QueryDeals(addrStr, product, side string, start, end, page, perPage int) ([]types.Deal, error)
 	QueryTransactions(addrStr string, typeCode, start, end, page, perPage int) ([]types.Transaction, error)
+
+	// market data queries with sdk.Dec
+	QueryCandlesDec(product string, granularity, size int) ([]types.Candle, error)
+	QueryTickersDec(product string, count ...int) ([]types.TickerDec, error)
+	QueryRecentTxRecordDec(product string, start, end, page, perPage int) ([]types.MatchResultDec, error)
+
+	// list queries with the pagination info
+	QueryRecentTxRecordWithPage(product string, start, end, page, perPage int) ([]types.MatchResult, types.ParamPage,
+		error)
+	QueryOpenOrdersWithPage(addrStr, product, side string, start, end, page, perPage int) ([]types.Order,
+		types.ParamPage, error)
+	QueryClosedOrdersWithPage(addrStr, product, side string, start, end, page, perPage int) ([]types.Order,
+		types.ParamPage, error)
+	QueryDealsWithPage(addrStr, product, side string, start, end, page, perPage int) ([]types.Deal, types.ParamPage,
+		error)
+	QueryTransactionsWithPage(addrStr string, typeCode, start, end, page, perPage int) ([]types.Transaction,
+		types.ParamPage, error)
+
+	// list queries over all pages
+	QueryAllRecentTxRecord(product string, start, end, limit int) ([]types.MatchResult, error)
+	QueryAllOpenOrders(addrStr, product, side string, start, end, limit int) ([]types.Order, error)
+	QueryAllClosedOrders(addrStr, product, side string, start, end, limit int) ([]types.Order, error)
+	QueryAllDeals(addrStr, product, side string, start, end, limit int) ([]types.Deal, error)
+	QueryAllTransactions(addrStr string, typeCode, start, end, limit int) ([]types.Transaction, error)
+
+	// list queries iterating over the pages one by one
+	ForEachRecentTxRecord(product string, start, end int, fn func(types.MatchResult) bool) error
+	ForEachOpenOrder(addrStr, product, side string, start, end int, fn func(types.Order) bool) error
+	ForEachClosedOrder(addrStr, product, side string, start, end int, fn func(types.Order) bool) error
+	ForEachDeal(addrStr, product, side string, start, end int, fn func(types.Deal) bool) error
+	ForEachTransaction(addrStr string, typeCode, start, end int, fn func(types.Transaction) bool) error
 }
@@ -21,15 +21,24 @@ type DexTx interface {
 	Deposit(fromInfo keys.Info, passWd, product, amountStr, memo string, accNum, seqNum uint64) (sdk.TxResponse, error)
 	Withdraw(fromInfo keys.Info, passWd, product, amountStr, memo string, accNum, seqNum uint64) (sdk.TxResponse, error)
 	TransferOwnership(fromInfo keys.Info, passWd, inputPath string, accNum, seqNum uint64) (sdk.TxResponse, error)
+	BroadcastTransferOwnershipTx(fromInfo keys.Info, passWd string, stdTx sdk.StdTx, accNum, seqNum uint64) (
+		sdk.TxResponse, error)
 }
 
 // DexOffline shows the expected tx behavior offline for inner dex client
 type DexOffline interface {
 	GenerateUnsignedTransferOwnershipTx(product, fromAddrStr, toAddrStr, memo, outputPath string) error
 	MultiSign(fromInfo keys.Info, passWd, inputPath, outputPath string) error
+	BuildUnsignedTransferOwnershipTx(product, fromAddrStr, toAddrStr, memo string) (sdk.StdTx, error)
+	SignTransferOwnershipTx(fromInfo keys.Info, passWd string, stdTx sdk.StdTx) (sdk.StdTx, error)
+	ValidateTransferOwnershipTx(stdTx sdk.StdTx) error
 }
 
 // DexQuery shows the expected query behavior for inner dex client
 type DexQuery interface {
 	QueryProducts(ownerAddr string, page, perPage int) ([]types.TokenPair, error)
+	QueryDeposits(ownerAddr string, page, perPage int) ([]types.DepositInfo, error)
+	QueryDepositRanking() ([]types.DepositRank, error)
+	QueryWithdrawInfo(ownerAddr string) ([]types.WithdrawInfo, error)
+	QueryParams() (types.Params, error)
 }
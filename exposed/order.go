@@ -18,10 +18,22 @@ type OrderTx interface {
 	NewOrders(fromInfo keys.Info, passWd, products, sides, prices, quantities, memo string, accNum, seqNum uint64) (
 		sdk.TxResponse, error)
 	CancelOrders(fromInfo keys.Info, passWd, orderIDs, memo string, accNum, seqNum uint64) (sdk.TxResponse, error)
+	NewOrdersWithItems(fromInfo keys.Info, passWd string, orderItems []types.OrderItem, memo string, accNum,
+		seqNum uint64) (sdk.TxResponse, []types.OrderResult, error)
+	CancelOrdersWithIDs(fromInfo keys.Info, passWd string, orderIDs []string, memo string, accNum, seqNum uint64) (
+		sdk.TxResponse, []types.OrderResult, error)
+	ReplaceOrders(fromInfo keys.Info, passWd string, orderIDs []string, orderItems []types.OrderItem, memo string,
+		accNum, seqNum uint64) (sdk.TxResponse, sdk.TxResponse, []types.ReplaceResult, error)
+	CancelOrdersInBatches(fromInfo keys.Info, passWd string, orderIDs []string, memo string, accNum, seqNum uint64) (
+		types.CancelOrdersResult, error)
 }
 
 // OrderQuery shows the expected query behavior for inner order client
 type OrderQuery interface {
 	QueryDepthBook(product string) (types.BookRes, error)
 	QueryOrderDetail(orderID string) (types.OrderDetail, error)
+	QueryParams() (types.Params, error)
+	QueryProductRule(product string) (types.ProductRule, error)
+	RefreshProductRules() error
+	RoundOrderItems(orderItems []types.OrderItem) ([]types.OrderItem, error)
 }
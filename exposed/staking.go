@@ -33,5 +33,6 @@ type StakingTx interface {
 type StakingQuery interface {
 	QueryValidators() ([]types.Validator, error)
 	QueryValidator(valAddrStr string) (types.Validator, error)
+	QueryValidatorViews(filter types.ValidatorFilter) ([]types.ValidatorView, error)
 	QueryDelegator(delAddrStr string) (types.DelegatorResp, error)
 }
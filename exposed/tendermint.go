@@ -17,7 +17,10 @@ type TendermintQuery interface {
 	QueryBlockResults(height int64) (types.BlockResults, error)
 	QueryCommitResult(height int64) (types.ResultCommit, error)
 	QueryValidatorsResult(height int64) (types.ResultValidators, error)
+	QueryLatestHeight() (int64, error)
 	QueryTxResult(txHash []byte, prove bool) (types.ResultTx, error)
 	// QueryTxsResult assumes the node to query a truth teller
 	QueryTxsResult(queryStr string, page, perPage int) (types.ResultTxs, error)
+	QueryTxsResultByQuery(query types.TxQuery, page, perPage int) (types.ResultTxs, error)
+	QueryAllTxsResult(query types.TxQuery) ([]types.ResultTx, error)
 }
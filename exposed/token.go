@@ -11,6 +11,7 @@ type Token interface {
 	sdk.Module
 	TokenTx
 	TokenQuery
+	TokenOffline
 }
 
 // TokenTx shows the expected tx behavior for inner token client
@@ -18,8 +19,26 @@ type TokenTx interface {
 	Send(fromInfo keys.Info, passWd, toAddrStr, coinsStr, memo string, accNum, seqNum uint64) (sdk.TxResponse, error)
 	MultiSend(fromInfo keys.Info, passWd string, transfers []types.TransferUnit, memo string, accNum, seqNum uint64) (
 		sdk.TxResponse, error)
+	BatchPayout(fromInfo keys.Info, passWd string, records []types.PayoutRecord, config types.PayoutConfig, memo string,
+		accNum, seqNum uint64) ([]types.PayoutRecord, error)
 	Issue(fromInfo keys.Info, passWd, orgSymbol, wholeName, totalSupply, tokenDesc, memo string, mintable bool, accNum,
 		seqNum uint64) (sdk.TxResponse, error)
+	Burn(fromInfo keys.Info, passWd, coinStr, memo string, accNum, seqNum uint64) (sdk.TxResponse, error)
+	Edit(fromInfo keys.Info, passWd, symbol, tokenDesc, wholeName string, isDescEdit, isWholeNameEdit bool,
+		memo string, accNum, seqNum uint64) (sdk.TxResponse, error)
+	Mint(fromInfo keys.Info, passWd, symbol string, amount int64, memo string, accNum, seqNum uint64) (sdk.TxResponse,
+		error)
+	TransferOwnership(fromInfo keys.Info, passWd, inputPath string, accNum, seqNum uint64) (sdk.TxResponse, error)
+	BroadcastTransferOwnershipTx(fromInfo keys.Info, passWd string, stdTx sdk.StdTx, accNum, seqNum uint64) (
+		sdk.TxResponse, error)
+}
+
+// TokenOffline shows the expected tx behavior offline for inner token client
+type TokenOffline interface {
+	GenerateUnsignedTransferOwnershipTx(symbol, fromAddrStr, toAddrStr, memo, outputPath string) error
+	MultiSign(fromInfo keys.Info, passWd, inputPath, outputPath string) error
+	BuildUnsignedTransferOwnershipTx(symbol, fromAddrStr, toAddrStr, memo string) (sdk.StdTx, error)
+	SignTransferOwnershipTx(fromInfo keys.Info, passWd string, stdTx sdk.StdTx) (sdk.StdTx, error)
 }
 
 // TokenQuery shows the expected query behavior for inner token client
@@ -27,4 +46,11 @@ type TokenQuery interface {
 	QueryTokenInfo(ownerAddr, symbol string) ([]types.Token, error)
 	QueryAccountTokensInfo(addrStr string) (types.AccountTokensInfo, error)
 	QueryAccountTokenInfo(addrStr, symbol string) (types.AccountTokensInfo, error)
+	QueryAccountTokensInfoDec(addrStr string) (types.AccountTokensInfoDec, error)
+	QueryAccountTokenInfoDec(addrStr, symbol string) (types.AccountTokensInfoDec, error)
+	QueryAllTokens() ([]types.Token, error)
+	QueryTokens(page, perPage int) ([]types.Token, error)
+	QueryTokenSupply(symbol string) (types.TokenSupply, error)
+	QueryTokenMeta(symbol string) (types.TokenMeta, error)
+	RefreshTokenMetas() error
 }
@@ -0,0 +1,294 @@
+package indexer
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/okex/okchain-go-sdk/exposed"
+	ordertypes "github.com/okex/okchain-go-sdk/module/order/types"
+	stakingtypes "github.com/okex/okchain-go-sdk/module/staking/types"
+	"github.com/okex/okchain-go-sdk/module/tendermint"
+	tmtypes "github.com/okex/okchain-go-sdk/module/tendermint/types"
+	tokentypes "github.com/okex/okchain-go-sdk/module/token/types"
+	sdk "github.com/okex/okchain-go-sdk/types"
+	"github.com/okex/okchain-go-sdk/utils"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// Indexer pulls blocks through the tendermint module and stores the transfers, orders and delegations of each address
+// in a local database
+type Indexer struct {
+	tq     exposed.TendermintQuery
+	db     dbm.DB
+	config Config
+
+	mtx      sync.Mutex
+	iterator *tendermint.BlockIterator
+	stopped  bool
+}
+
+// NewIndexer creates a new instance of Indexer
+func NewIndexer(tq exposed.TendermintQuery, db dbm.DB, config Config) *Indexer {
+	return &Indexer{
+		tq:     tq,
+		db:     db,
+		config: config,
+	}
+}
+
+// Run indexes the blocks from the checkpoint in the store, or from the start height if there is none. It blocks until
+// the chain head is reached, an error occurs or Stop is called
+func (idx *Indexer) Run() error {
+	iteratorConfig := tendermint.BlockIteratorConfig{
+		From:         idx.config.StartHeight,
+		Workers:      idx.config.Workers,
+		Follow:       idx.config.Follow,
+		PollInterval: idx.config.PollInterval,
+	}
+	if checkpoint := idx.Checkpoint(); checkpoint >= iteratorConfig.From {
+		iteratorConfig = iteratorConfig.ResumeFrom(checkpoint)
+	}
+
+	idx.mtx.Lock()
+	if idx.stopped {
+		idx.mtx.Unlock()
+		return nil
+	}
+	iterator, err := tendermint.NewBlockIterator(idx.tq, iteratorConfig)
+	if err != nil {
+		idx.mtx.Unlock()
+		return err
+	}
+	idx.iterator = iterator
+	idx.mtx.Unlock()
+
+	for iterator.Next() {
+		if err = idx.IndexBlock(iterator.Value()); err != nil {
+			iterator.Close()
+			return err
+		}
+	}
+
+	return iterator.Err()
+}
+
+// Stop stops the running indexer
+func (idx *Indexer) Stop() {
+	idx.mtx.Lock()
+	defer idx.mtx.Unlock()
+	idx.stopped = true
+	if idx.iterator != nil {
+		idx.iterator.Close()
+	}
+}
+
+// Checkpoint returns the height of the last indexed block
+func (idx *Indexer) Checkpoint() int64 {
+	return getCheckpoint(idx.db)
+}
+
+// IndexBlock decodes the successful txs in a block and writes their records with the checkpoint atomically
+func (idx *Indexer) IndexBlock(item tmtypes.BlockWithResults) error {
+	block, deliverTxs := item.Block, item.Results.Results.DeliverTx
+	if len(block.Txs) != len(deliverTxs) {
+		return fmt.Errorf("failed. %d txs but %d results on height %d", len(block.Txs), len(deliverTxs),
+			block.Height)
+	}
+
+	batch := idx.db.NewBatch()
+	defer batch.Close()
+
+	for i, stdTx := range block.Txs {
+		if deliverTxs[i].Code != 0 {
+			continue
+		}
+
+		var txHash string
+		if i < len(block.TxHashes) {
+			txHash = block.TxHashes[i].String()
+		}
+
+		tw := txWriter{
+			batch:     batch,
+			height:    block.Height,
+			txIndex:   i,
+			txHash:    txHash,
+			timestamp: block.Time,
+			orders:    getOrderResults(deliverTxs[i]),
+		}
+		for _, msg := range stdTx.Msgs {
+			if err := tw.writeMsg(msg); err != nil {
+				return err
+			}
+		}
+	}
+
+	setCheckpoint(batch, block.Height)
+	batch.WriteSync()
+	return nil
+}
+
+// QueryTransfers gets the transfers sent or received by an address, from the newest to the oldest
+func (idx *Indexer) QueryTransfers(addrStr string, page, perPage int) (records []TransferRecord, err error) {
+	err = iterateRecords(idx.db, transferPrefix, addrStr, page, perPage, func(bz []byte) error {
+		var record TransferRecord
+		if err := json.Unmarshal(bz, &record); err != nil {
+			return err
+		}
+		records = append(records, record)
+		return nil
+	})
+	return
+}
+
+// QueryOrders gets the orders placed or cancelled by an address, from the newest to the oldest
+func (idx *Indexer) QueryOrders(addrStr string, page, perPage int) (records []OrderRecord, err error) {
+	err = iterateRecords(idx.db, orderPrefix, addrStr, page, perPage, func(bz []byte) error {
+		var record OrderRecord
+		if err := json.Unmarshal(bz, &record); err != nil {
+			return err
+		}
+		records = append(records, record)
+		return nil
+	})
+	return
+}
+
+// QueryDelegations gets the staking operations of a delegator, from the newest to the oldest
+func (idx *Indexer) QueryDelegations(addrStr string, page, perPage int) (records []DelegationRecord, err error) {
+	err = iterateRecords(idx.db, delegationPrefix, addrStr, page, perPage, func(bz []byte) error {
+		var record DelegationRecord
+		if err := json.Unmarshal(bz, &record); err != nil {
+			return err
+		}
+		records = append(records, record)
+		return nil
+	})
+	return
+}
+
+// txWriter writes the records of the msgs in a tx
+type txWriter struct {
+	batch     dbm.SetDeleter
+	height    int64
+	txIndex   int
+	txHash    string
+	timestamp time.Time
+	orders    []ordertypes.OrderResult
+	seq       int
+}
+
+func (tw *txWriter) write(kindPrefix []byte, addr sdk.AccAddress, record interface{}) error {
+	key := recordKey(kindPrefix, addr, tw.height, tw.txIndex, tw.seq)
+	tw.seq++
+	return setRecord(tw.batch, key, record)
+}
+
+func (tw *txWriter) writeTransfer(from, to sdk.AccAddress, coins sdk.DecCoins) error {
+	record := TransferRecord{
+		Height:    tw.height,
+		TxHash:    tw.txHash,
+		Timestamp: tw.timestamp,
+		From:      from,
+		To:        to,
+		Coins:     coins,
+	}
+	if err := tw.write(transferPrefix, from, record); err != nil {
+		return err
+	}
+	if to.Equals(from) {
+		return nil
+	}
+	return tw.write(transferPrefix, to, record)
+}
+
+func (tw *txWriter) writeDelegation(delegator sdk.AccAddress, action string, amount sdk.DecCoin,
+	validators []sdk.ValAddress, proxy sdk.AccAddress) error {
+	return tw.write(delegationPrefix, delegator, DelegationRecord{
+		Height:     tw.height,
+		TxHash:     tw.txHash,
+		Timestamp:  tw.timestamp,
+		Delegator:  delegator,
+		Action:     action,
+		Amount:     amount,
+		Validators: validators,
+		Proxy:      proxy,
+	})
+}
+
+func (tw *txWriter) writeMsg(msg sdk.Msg) error {
+	switch msg := msg.(type) {
+	case tokentypes.MsgSend:
+		return tw.writeTransfer(msg.FromAddress, msg.ToAddress, msg.Amount)
+	case tokentypes.MsgMultiSend:
+		for _, transfer := range msg.Transfers {
+			if err := tw.writeTransfer(msg.From, transfer.To, transfer.Coins); err != nil {
+				return err
+			}
+		}
+	case ordertypes.MsgNewOrders:
+		for _, item := range msg.OrderItems {
+			record := OrderRecord{
+				Height:    tw.height,
+				TxHash:    tw.txHash,
+				Timestamp: tw.timestamp,
+				Sender:    msg.Sender,
+				Action:    OrderActionNew,
+				Product:   item.Product,
+				Side:      item.Side.String(),
+				Price:     item.Price,
+				Quantity:  item.Quantity,
+			}
+			// the order results are emitted in the same order as the items
+			if len(tw.orders) != 0 {
+				record.OrderID = tw.orders[0].OrderID
+				tw.orders = tw.orders[1:]
+			}
+			if err := tw.write(orderPrefix, msg.Sender, record); err != nil {
+				return err
+			}
+		}
+	case ordertypes.MsgCancelOrders:
+		for _, orderID := range msg.OrderIDs {
+			if err := tw.write(orderPrefix, msg.Sender, OrderRecord{
+				Height:    tw.height,
+				TxHash:    tw.txHash,
+				Timestamp: tw.timestamp,
+				Sender:    msg.Sender,
+				Action:    OrderActionCancel,
+				OrderID:   orderID,
+			}); err != nil {
+				return err
+			}
+		}
+	case stakingtypes.MsgDelegate:
+		return tw.writeDelegation(msg.DelegatorAddress, DelegationActionDelegate, msg.Amount, nil, nil)
+	case stakingtypes.MsgUndelegate:
+		return tw.writeDelegation(msg.DelegatorAddress, DelegationActionUndelegate, msg.Amount, nil, nil)
+	case stakingtypes.MsgVote:
+		return tw.writeDelegation(msg.DelAddr, DelegationActionVote, sdk.DecCoin{}, msg.ValAddrs, nil)
+	case stakingtypes.MsgBindProxy:
+		return tw.writeDelegation(msg.DelAddr, DelegationActionBindProxy, sdk.DecCoin{}, nil, msg.ProxyAddress)
+	case stakingtypes.MsgUnbindProxy:
+		return tw.writeDelegation(msg.DelAddr, DelegationActionUnbindProxy, sdk.DecCoin{}, nil, nil)
+	}
+
+	return nil
+}
+
+// getOrderResults collects the order results of the msgs of placing orders in a tx
+func getOrderResults(deliverTx tmtypes.ResponseDeliverTx) (orderRes []ordertypes.OrderResult) {
+	// the order ids are only informative in the records, so malformed events don't stop the indexing
+	ordersEvents, err := utils.ParseNewOrderEvents(utils.GetEventsFromDeliverTx(deliverTx))
+	if err != nil {
+		return nil
+	}
+
+	for _, ordersEvent := range ordersEvents {
+		orderRes = append(orderRes, ordersEvent.Results...)
+	}
+
+	return
+}
@@ -0,0 +1,211 @@
+package indexer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/okex/okchain-go-sdk/mocks"
+	ordertypes "github.com/okex/okchain-go-sdk/module/order/types"
+	stakingtypes "github.com/okex/okchain-go-sdk/module/staking/types"
+	"github.com/okex/okchain-go-sdk/module/tendermint"
+	tmtypes "github.com/okex/okchain-go-sdk/module/tendermint/types"
+	tokentypes "github.com/okex/okchain-go-sdk/module/token/types"
+	sdk "github.com/okex/okchain-go-sdk/types"
+	"github.com/stretchr/testify/require"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmbasetypes "github.com/tendermint/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+)
+
+const (
+	addr     = "okchain1dcsxvxgj374dv3wt9szflf9nz6342juzzkjnlz"
+	addr1    = "okchain1alq9na49n9yycysh889rl90g9nhe58lcv27tfj"
+	valAddr  = "okchainvaloper1alq9na49n9yycysh889rl90g9nhe58lcs50wu5"
+	orderID  = "ID0000000001-1"
+	orderID1 = "ID0000000001-2"
+)
+
+func newBlockWithResults(height int64, stdTxs []sdk.StdTx, deliverTxs []tmtypes.ResponseDeliverTx) tmtypes.BlockWithResults {
+	txHashes := make([]cmn.HexBytes, len(stdTxs))
+	for i := range txHashes {
+		txHashes[i] = cmn.HexBytes{byte(height), byte(i)}
+	}
+
+	return tmtypes.BlockWithResults{
+		Block: tmtypes.NewBlock(tmbasetypes.Header{Height: height, Time: time.Unix(height, 0).UTC()},
+			tmtypes.NewDataWithHashes(stdTxs, txHashes), tmbasetypes.EvidenceData{}, tmbasetypes.Commit{}),
+		Results: tmtypes.BlockResults{
+			Height: height,
+			Results: tmtypes.ABCIResponses{
+				DeliverTx: deliverTxs,
+			},
+		},
+	}
+}
+
+func TestIndexer_IndexBlock(t *testing.T) {
+	from, err := sdk.AccAddressFromBech32(addr)
+	require.NoError(t, err)
+	to, err := sdk.AccAddressFromBech32(addr1)
+	require.NoError(t, err)
+	val, err := sdk.ValAddressFromBech32(valAddr)
+	require.NoError(t, err)
+	coins, err := sdk.ParseDecCoins("1.024okt")
+	require.NoError(t, err)
+	coin, err := sdk.ParseDecCoin("10.24okt")
+	require.NoError(t, err)
+
+	idx := NewIndexer(nil, dbm.NewMemDB(), DefaultConfig())
+	require.Equal(t, int64(0), idx.Checkpoint())
+
+	// block 1 with a transfer, a failed transfer and an order placing
+	orderItems := []ordertypes.OrderItem{
+		ordertypes.NewOrderItem("btc-000_okt", "BUY", "10.24", "1.024"),
+		ordertypes.NewOrderItem("btc-000_okt", "SELL", "20.48", "2.048"),
+	}
+	stdTxs := []sdk.StdTx{
+		sdk.NewStdTx([]sdk.Msg{tokentypes.NewMsgTokenSend(from, to, coins)}, sdk.StdFee{}, nil, ""),
+		sdk.NewStdTx([]sdk.Msg{tokentypes.NewMsgTokenSend(to, from, coins)}, sdk.StdFee{}, nil, ""),
+		sdk.NewStdTx([]sdk.Msg{ordertypes.NewMsgNewOrders(from, orderItems)}, sdk.StdFee{}, nil, ""),
+	}
+	deliverTxs := []tmtypes.ResponseDeliverTx{
+		{},
+		{Code: 1},
+		{
+			Events: []tmtypes.Event{
+				{
+					Type:       "message",
+					Attributes: []tmtypes.KVPair{{Key: []byte("action"), Value: []byte("new")}},
+				},
+				{
+					Type:       "message",
+					Attributes: []tmtypes.KVPair{{Key: []byte("sender"), Value: []byte(from.String())}},
+				},
+				{
+					Type: "message",
+					Attributes: []tmtypes.KVPair{
+						{Key: []byte("orders"), Value: []byte(`[{"code":0,"msg":"","orderid":"` + orderID +
+							`"},{"code":0,"msg":"","orderid":"` + orderID1 + `"}]`)},
+					},
+				},
+			},
+		},
+	}
+	require.NoError(t, idx.IndexBlock(newBlockWithResults(1, stdTxs, deliverTxs)))
+	require.Equal(t, int64(1), idx.Checkpoint())
+
+	// block 2 with an order cancelling and staking operations
+	stdTxs = []sdk.StdTx{
+		sdk.NewStdTx([]sdk.Msg{ordertypes.NewMsgCancelOrders(from, []string{orderID})}, sdk.StdFee{}, nil, ""),
+		sdk.NewStdTx([]sdk.Msg{
+			stakingtypes.NewMsgDelegate(from, coin),
+			stakingtypes.NewMsgVote(from, []sdk.ValAddress{val}),
+		}, sdk.StdFee{}, nil, ""),
+	}
+	require.NoError(t, idx.IndexBlock(newBlockWithResults(2, stdTxs, make([]tmtypes.ResponseDeliverTx, 2))))
+	require.Equal(t, int64(2), idx.Checkpoint())
+
+	// transfers
+	transfers, err := idx.QueryTransfers(addr, 1, 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(transfers))
+	require.Equal(t, int64(1), transfers[0].Height)
+	require.Equal(t, cmn.HexBytes{1, 0}.String(), transfers[0].TxHash)
+	require.Equal(t, from, transfers[0].From)
+	require.Equal(t, to, transfers[0].To)
+	require.Equal(t, coins, transfers[0].Coins)
+
+	transfers, err = idx.QueryTransfers(addr1, 1, 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(transfers))
+	require.Equal(t, from, transfers[0].From)
+
+	// orders from the newest to the oldest
+	orders, err := idx.QueryOrders(addr, 1, 10)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(orders))
+	require.Equal(t, OrderActionCancel, orders[0].Action)
+	require.Equal(t, orderID, orders[0].OrderID)
+	require.Equal(t, OrderActionNew, orders[1].Action)
+	require.Equal(t, orderID1, orders[1].OrderID)
+	require.Equal(t, "SELL", orders[1].Side)
+	require.Equal(t, orderID, orders[2].OrderID)
+	require.Equal(t, sdk.MustNewDecFromStr("10.24"), orders[2].Price)
+	require.Equal(t, sdk.MustNewDecFromStr("1.024"), orders[2].Quantity)
+
+	// pagination
+	orders, err = idx.QueryOrders(addr, 2, 2)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(orders))
+	require.Equal(t, orderID, orders[0].OrderID)
+	require.Equal(t, OrderActionNew, orders[0].Action)
+
+	orders, err = idx.QueryOrders(addr1, 1, 10)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(orders))
+
+	// delegations
+	delegations, err := idx.QueryDelegations(addr, 1, 10)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(delegations))
+	require.Equal(t, DelegationActionVote, delegations[0].Action)
+	require.Equal(t, []sdk.ValAddress{val}, delegations[0].Validators)
+	require.Equal(t, DelegationActionDelegate, delegations[1].Action)
+	require.Equal(t, coin, delegations[1].Amount)
+
+	// bad query
+	_, err = idx.QueryTransfers(addr[1:], 1, 10)
+	require.Error(t, err)
+	_, err = idx.QueryTransfers(addr, 0, 10)
+	require.Error(t, err)
+	_, err = idx.QueryTransfers(addr, 1, 0)
+	require.Error(t, err)
+
+	// mismatched results
+	require.Error(t, idx.IndexBlock(newBlockWithResults(3, stdTxs, nil)))
+	require.Equal(t, int64(2), idx.Checkpoint())
+}
+
+func TestIndexer_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := sdk.NewClientConfig("testURL", "testChain", sdk.BroadcastBlock, "0.01okt", 200000)
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(tendermint.NewTendermintClient(mockCli.MockBaseClient))
+
+	expectedCdc := mockCli.GetCodec()
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).AnyTimes()
+	mockCli.EXPECT().Block(gomock.Any()).DoAndReturn(func(height *int64) (*ctypes.ResultBlock, error) {
+		return mockCli.GetRawResultBlockPointer("default chainID", *height, time.Now(), cmn.HexBytes("app hash"),
+			cmn.HexBytes("block ID hash")), nil
+	}).AnyTimes()
+	mockCli.EXPECT().BlockResults(gomock.Any()).DoAndReturn(func(height *int64) (*ctypes.ResultBlockResults, error) {
+		return mockCli.GetRawResultBlockResultsPointer(1, *height, "pubkey type", "event type",
+			[]byte("kv pair key")), nil
+	}).AnyTimes()
+	gomock.InOrder(
+		mockCli.EXPECT().Commit(nil).Return(mockCli.GetRawCommitResultPointer(true, "default chainID", 10,
+			time.Now(), cmn.HexBytes("app hash"), cmn.HexBytes("block ID hash")), nil),
+		mockCli.EXPECT().Commit(nil).Return(mockCli.GetRawCommitResultPointer(true, "default chainID", 15,
+			time.Now(), cmn.HexBytes("app hash"), cmn.HexBytes("block ID hash")), nil),
+	)
+
+	indexerConfig := DefaultConfig()
+	indexerConfig.StartHeight = 5
+	indexerConfig.Follow = false
+	idx := NewIndexer(mockCli.Tendermint(), dbm.NewMemDB(), indexerConfig)
+	require.NoError(t, idx.Run())
+	require.Equal(t, int64(10), idx.Checkpoint())
+
+	// resume from the checkpoint
+	require.NoError(t, idx.Run())
+	require.Equal(t, int64(15), idx.Checkpoint())
+
+	// stopped
+	idx.Stop()
+	require.NoError(t, idx.Run())
+	require.Equal(t, int64(15), idx.Checkpoint())
+}
@@ -0,0 +1,105 @@
+package indexer
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+
+	sdk "github.com/okex/okchain-go-sdk/types"
+	"github.com/okex/okchain-go-sdk/utils"
+	dbm "github.com/tendermint/tm-db"
+)
+
+var (
+	checkpointKey    = []byte{0x00}
+	transferPrefix   = []byte{0x01}
+	orderPrefix      = []byte{0x02}
+	delegationPrefix = []byte{0x03}
+)
+
+// addressPrefix builds the key prefix of all records of an address in a kind
+func addressPrefix(kindPrefix []byte, addr sdk.AccAddress) []byte {
+	key := make([]byte, 0, len(kindPrefix)+1+len(addr))
+	key = append(key, kindPrefix...)
+	key = append(key, byte(len(addr)))
+	return append(key, addr...)
+}
+
+// recordKey builds the key of a record, which is sorted by height, tx index and the sequence in the tx
+func recordKey(kindPrefix []byte, addr sdk.AccAddress, height int64, txIndex, seq int) []byte {
+	key := addressPrefix(kindPrefix, addr)
+	bz := make([]byte, 16)
+	binary.BigEndian.PutUint64(bz[:8], uint64(height))
+	binary.BigEndian.PutUint32(bz[8:12], uint32(txIndex))
+	binary.BigEndian.PutUint32(bz[12:], uint32(seq))
+	return append(key, bz...)
+}
+
+// prefixEnd returns the end key for the iteration over a prefix
+func prefixEnd(prefix []byte) []byte {
+	end := make([]byte, len(prefix))
+	copy(end, prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		end[i]++
+		if end[i] != 0 {
+			return end[:i+1]
+		}
+	}
+	return nil
+}
+
+func getCheckpoint(db dbm.DB) int64 {
+	bz := db.Get(checkpointKey)
+	if len(bz) != 8 {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(bz))
+}
+
+func setCheckpoint(batch dbm.SetDeleter, height int64) {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(height))
+	batch.Set(checkpointKey, bz)
+}
+
+func setRecord(batch dbm.SetDeleter, key []byte, record interface{}) error {
+	bz, err := json.Marshal(record)
+	if err != nil {
+		return utils.ErrMarshalJSON(err.Error())
+	}
+	batch.Set(key, bz)
+	return nil
+}
+
+// iterateRecords walks through the records of an address from the newest to the oldest and unmarshals the ones on
+// the page with the callback
+func iterateRecords(db dbm.DB, kindPrefix []byte, addrStr string, page, perPage int,
+	unmarshal func(bz []byte) error) error {
+	if page <= 0 {
+		return errors.New("failed. page must be greater than 0")
+	}
+	if perPage <= 0 {
+		return errors.New("failed. limit number in a page must be greater than 0")
+	}
+
+	addr, err := sdk.AccAddressFromBech32(addrStr)
+	if err != nil {
+		return err
+	}
+
+	prefix := addressPrefix(kindPrefix, addr)
+	iter := db.ReverseIterator(prefix, prefixEnd(prefix))
+	defer iter.Close()
+
+	skip := (page - 1) * perPage
+	for count := 0; iter.Valid() && count < skip+perPage; iter.Next() {
+		if count >= skip {
+			if err := unmarshal(iter.Value()); err != nil {
+				return utils.ErrUnmarshalJSON(err.Error())
+			}
+		}
+		count++
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package indexer
+
+import (
+	"time"
+
+	sdk "github.com/okex/okchain-go-sdk/types"
+)
+
+// const
+const (
+	OrderActionNew    = "new"
+	OrderActionCancel = "cancel"
+
+	DelegationActionDelegate    = "delegate"
+	DelegationActionUndelegate  = "undelegate"
+	DelegationActionVote        = "vote"
+	DelegationActionBindProxy   = "bind_proxy"
+	DelegationActionUnbindProxy = "unbind_proxy"
+)
+
+// TransferRecord - structure of a transfer related to an address
+type TransferRecord struct {
+	Height    int64          `json:"height"`
+	TxHash    string         `json:"txhash"`
+	Timestamp time.Time      `json:"timestamp"`
+	From      sdk.AccAddress `json:"from"`
+	To        sdk.AccAddress `json:"to"`
+	Coins     sdk.DecCoins   `json:"coins"`
+}
+
+// OrderRecord - structure of an order placed or cancelled by an address
+type OrderRecord struct {
+	Height    int64          `json:"height"`
+	TxHash    string         `json:"txhash"`
+	Timestamp time.Time      `json:"timestamp"`
+	Sender    sdk.AccAddress `json:"sender"`
+	Action    string         `json:"action"`
+	OrderID   string         `json:"order_id"`
+	Product   string         `json:"product,omitempty"`
+	Side      string         `json:"side,omitempty"`
+	Price     sdk.Dec        `json:"price"`
+	Quantity  sdk.Dec        `json:"quantity"`
+}
+
+// DelegationRecord - structure of a staking operation of a delegator
+type DelegationRecord struct {
+	Height     int64            `json:"height"`
+	TxHash     string           `json:"txhash"`
+	Timestamp  time.Time        `json:"timestamp"`
+	Delegator  sdk.AccAddress   `json:"delegator"`
+	Action     string           `json:"action"`
+	Amount     sdk.DecCoin      `json:"amount"`
+	Validators []sdk.ValAddress `json:"validators,omitempty"`
+	Proxy      sdk.AccAddress   `json:"proxy,omitempty"`
+}
+
+// Config - structure of the config for Indexer
+type Config struct {
+	// StartHeight is the first height to index when there is no checkpoint in the store
+	StartHeight int64
+	// Workers limits the number of blocks fetched concurrently
+	Workers int
+	// Follow keeps indexing the new blocks once the chain head is reached
+	Follow bool
+	// PollInterval is the interval to query the latest height while following the chain head
+	PollInterval time.Duration
+}
+
+// DefaultConfig returns the default config of Indexer that indexes from the genesis and follows the chain head
+func DefaultConfig() Config {
+	return Config{
+		StartHeight:  1,
+		Workers:      4,
+		Follow:       true,
+		PollInterval: time.Second,
+	}
+}
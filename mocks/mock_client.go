@@ -512,6 +512,24 @@ func (mc *MockClient) BuildBackendTransactionsResultBytes(txHash, accAddr, symbo
 	return bytes
 }
 
+// BuildBackendListResponseBytes generates the backend list response bytes with the pagination info for test
+func (mc *MockClient) BuildBackendListResponseBytes(data interface{}, page, perPage, total int) []byte {
+	listResp := backend.ListResponse{
+		Data: backend.ListDataRes{
+			Data: data,
+			ParamPage: backend.ParamPage{
+				Page:    page,
+				PerPage: perPage,
+				Total:   total,
+			},
+		},
+	}
+
+	bytes, err := json.Marshal(listResp)
+	require.NoError(mc.t, err)
+	return bytes
+}
+
 // BuildBackendCandlesBytes generates the backend candles bytes for test
 func (mc *MockClient) BuildBackendCandlesBytes(candles [][]string) []byte {
 	baseResp := backend.BaseResponse{
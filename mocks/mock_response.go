@@ -1,6 +1,9 @@
 package mocks
 
 import (
+	"encoding/json"
+
+	ordertypes "github.com/okex/okchain-go-sdk/module/order/types"
 	sdk "github.com/okex/okchain-go-sdk/types"
 )
 
@@ -22,3 +25,30 @@ func DefaultMockSuccessTxResponse() sdk.TxResponse {
 		Events:    nil,
 	}
 }
+
+// MockOrdersTxResponse returns the mock success tx response with the event of placing or cancelling orders
+func MockOrdersTxResponse(action, sender string, results []ordertypes.OrderResult) sdk.TxResponse {
+	resultsBytes, err := json.Marshal(results)
+	if err != nil {
+		panic(err)
+	}
+
+	txResp := DefaultMockSuccessTxResponse()
+	txResp.Logs = sdk.ABCIMessageLogs{
+		{
+			Success: true,
+			Events: sdk.StringEvents{
+				{
+					Type: ordertypes.EventTypeMessage,
+					Attributes: []sdk.Attribute{
+						{Key: ordertypes.AttributeKeyAction, Value: action},
+						{Key: ordertypes.AttributeKeySender, Value: sender},
+						{Key: ordertypes.AttributeKeyOrders, Value: string(resultsBytes)},
+					},
+				},
+			},
+		},
+	}
+
+	return txResp
+}
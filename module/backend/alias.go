@@ -9,8 +9,14 @@ const (
 
 type (
 	// nolint
-	Ticker      = types.Ticker
-	MatchResult = types.MatchResult
-	Order       = types.Order
-	Deal        = types.Deal
+	Ticker         = types.Ticker
+	TickerDec      = types.TickerDec
+	MatchResult    = types.MatchResult
+	MatchResultDec = types.MatchResultDec
+	Candle         = types.Candle
+	Order          = types.Order
+	Deal           = types.Deal
+	Transaction    = types.Transaction
+	ParamPage      = types.ParamPage
+	ResponseError  = types.ResponseError
 )
@@ -0,0 +1,105 @@
+package backend
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/okex/okchain-go-sdk/module/backend/types"
+	"github.com/okex/okchain-go-sdk/types/params"
+)
+
+const (
+	// pagingWorkers limits the number of pages fetched concurrently
+	pagingWorkers = 4
+)
+
+// pageFetcher fetches a page of a list query into the slice pointer and returns the pagination info
+type pageFetcher func(page, perPage int, ptr interface{}) (types.ParamPage, error)
+
+// queryAllPages fetches the first page to learn the total, then fetches the rest pages concurrently and appends all the
+// items into the slice pointer in page order. A positive limit caps the number of the items
+func queryAllPages(ptr interface{}, limit int, fetch pageFetcher) error {
+	sliceValue := reflect.ValueOf(ptr).Elem()
+	perPage := params.PerPageMax
+	if limit > 0 && limit < perPage {
+		perPage = limit
+	}
+
+	firstPage := reflect.New(sliceValue.Type())
+	paramPage, err := fetch(1, perPage, firstPage.Interface())
+	if err != nil {
+		return err
+	}
+
+	total := paramPage.Total
+	if limit > 0 && limit < total {
+		total = limit
+	}
+	// the backend may cut the per page number down
+	if paramPage.PerPage > 0 && paramPage.PerPage < perPage {
+		perPage = paramPage.PerPage
+	}
+	pagesNum := (total + perPage - 1) / perPage
+	if pagesNum < 1 {
+		pagesNum = 1
+	}
+
+	pages := make([]reflect.Value, pagesNum+1)
+	pages[1] = firstPage
+	errs := make([]error, pagesNum+1)
+	sem := make(chan struct{}, pagingWorkers)
+	var wg sync.WaitGroup
+	for page := 2; page <= pagesNum; page++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(page int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			pages[page] = reflect.New(sliceValue.Type())
+			_, errs[page] = fetch(page, perPage, pages[page].Interface())
+		}(page)
+	}
+	wg.Wait()
+
+	items := sliceValue
+	for page := 1; page < len(pages); page++ {
+		if errs[page] != nil {
+			return errs[page]
+		}
+		items = reflect.AppendSlice(items, pages[page].Elem())
+	}
+
+	if limit > 0 && items.Len() > limit {
+		items = items.Slice(0, limit)
+	}
+	sliceValue.Set(items)
+	return nil
+}
+
+// forEachPage fetches the pages one by one in page order into the slice pointer, and calls visit after each page until
+// the last page is fetched or visit returns false. Only one page is held in memory at a time
+func forEachPage(ptr interface{}, fetch pageFetcher, visit func() bool) error {
+	sliceValue := reflect.ValueOf(ptr).Elem()
+	perPage := params.PerPageMax
+	for page := 1; ; page++ {
+		sliceValue.Set(reflect.Zero(sliceValue.Type()))
+		paramPage, err := fetch(page, perPage, ptr)
+		if err != nil {
+			return err
+		}
+
+		if sliceValue.Len() == 0 || !visit() {
+			return nil
+		}
+
+		// the backend may cut the per page number down
+		if page == 1 && paramPage.PerPage > 0 && paramPage.PerPage < perPage {
+			perPage = paramPage.PerPage
+		}
+		if sliceValue.Len() < perPage || page*perPage >= paramPage.Total {
+			return nil
+		}
+	}
+}
@@ -20,12 +20,22 @@ func (bc backendClient) QueryCandles(product string, granularity, size int) (can
 	}
 
 	if err = utils.GetDataFromBaseResponse(res, &candles); err != nil {
-		return candles, utils.ErrFilterDataFromBaseResponse("candles", err.Error())
+		return candles, filterErr(err, utils.ErrFilterDataFromBaseResponse, "candles")
 	}
 
 	return
 }
 
+// QueryCandlesDec gets the typed candles data of a specific product
+func (bc backendClient) QueryCandlesDec(product string, granularity, size int) (candles []types.Candle, err error) {
+	rawCandles, err := bc.QueryCandles(product, granularity, size)
+	if err != nil {
+		return
+	}
+
+	return types.ParseCandles(rawCandles)
+}
+
 // QueryTickers gets all tickers' data
 // NOTE: all products are involved with setting "" to product
 func (bc backendClient) QueryTickers(product string, count ...int) (tickers []types.Ticker, err error) {
@@ -46,7 +56,25 @@ func (bc backendClient) QueryTickers(product string, count ...int) (tickers []ty
 	}
 
 	if err = utils.GetDataFromBaseResponse(res, &tickers); err != nil {
-		return tickers, utils.ErrFilterDataFromBaseResponse("tickers", err.Error())
+		return tickers, filterErr(err, utils.ErrFilterDataFromBaseResponse, "tickers")
+	}
+
+	return
+}
+
+// QueryTickersDec gets all tickers' data with the prices and volumes in sdk.Dec
+// NOTE: all products are involved with setting "" to product
+func (bc backendClient) QueryTickersDec(product string, count ...int) (tickersDec []types.TickerDec, err error) {
+	tickers, err := bc.QueryTickers(product, count...)
+	if err != nil {
+		return
+	}
+
+	tickersDec = make([]types.TickerDec, len(tickers))
+	for i, ticker := range tickers {
+		if tickersDec[i], err = ticker.ToDec(); err != nil {
+			return nil, err
+		}
 	}
 
 	return
@@ -55,129 +83,296 @@ func (bc backendClient) QueryTickers(product string, count ...int) (tickers []ty
 // QueryRecentTxRecord gets the specific product's record of recent transactions
 func (bc backendClient) QueryRecentTxRecord(product string, start, end, page, perPage int) (record []types.MatchResult,
 	err error) {
+	record, _, err = bc.QueryRecentTxRecordWithPage(product, start, end, page, perPage)
+	return
+}
+
+// QueryRecentTxRecordWithPage gets the specific product's record of recent transactions with the pagination info
+func (bc backendClient) QueryRecentTxRecordWithPage(product string, start, end, page, perPage int) (
+	record []types.MatchResult, paramPage types.ParamPage, err error) {
 	perPageNum, err := params.CheckQueryRecentTxRecordParams(product, start, end, page, perPage)
 	if err != nil {
 		return
 	}
 
 	matchParams := params.NewQueryMatchParams(product, int64(start), int64(end), page, perPageNum)
-	jsonBytes, err := bc.GetCodec().MarshalJSON(matchParams)
-	if err != nil {
-		return record, utils.ErrMarshalJSON(err.Error())
-	}
+	paramPage, err = bc.queryList(types.RecentTxRecordPath, "recent tx record", matchParams, &record, utils.ErrFilterDataFromListResponse)
+	return
+}
 
-	res, err := bc.Query(types.RecentTxRecordPath, jsonBytes)
+// QueryRecentTxRecordDec gets the specific product's record of recent transactions with the price and quantity in
+// sdk.Dec, which are decoded from the raw response without the loss of float64
+func (bc backendClient) QueryRecentTxRecordDec(product string, start, end, page, perPage int) (
+	record []types.MatchResultDec, err error) {
+	perPageNum, err := params.CheckQueryRecentTxRecordParams(product, start, end, page, perPage)
 	if err != nil {
-		return record, utils.ErrClientQuery(err.Error())
+		return
 	}
 
-	if err = utils.UnmarshalListResponse(res, &record); err != nil {
-		return record, utils.ErrFilterDataFromListResponse("recent tx record", err.Error())
-	}
+	matchParams := params.NewQueryMatchParams(product, int64(start), int64(end), page, perPageNum)
+	_, err = bc.queryList(types.RecentTxRecordPath, "recent tx record", matchParams, &record, utils.ErrFilterDataFromListResponse)
+	return
+}
 
+// QueryAllRecentTxRecord gets the specific product's record of recent transactions over all pages
+// NOTE: the pages are fetched concurrently. A positive limit caps the number of the records returned
+func (bc backendClient) QueryAllRecentTxRecord(product string, start, end, limit int) (record []types.MatchResult,
+	err error) {
+	err = queryAllPages(&record, limit, func(page, perPage int, ptr interface{}) (types.ParamPage, error) {
+		pageRecord, paramPage, err := bc.QueryRecentTxRecordWithPage(product, start, end, page, perPage)
+		*ptr.(*[]types.MatchResult) = pageRecord
+		return paramPage, err
+	})
 	return
 }
 
+// ForEachRecentTxRecord calls fn with the specific product's record of recent transactions in order, fetching the pages
+// one by one. The iteration stops once fn returns false
+func (bc backendClient) ForEachRecentTxRecord(product string, start, end int, fn func(types.MatchResult) bool) error {
+	var record []types.MatchResult
+	return forEachPage(&record, func(page, perPage int, ptr interface{}) (types.ParamPage, error) {
+		pageRecord, paramPage, err := bc.QueryRecentTxRecordWithPage(product, start, end, page, perPage)
+		*ptr.(*[]types.MatchResult) = pageRecord
+		return paramPage, err
+	}, func() bool {
+		for _, matchResult := range record {
+			if !fn(matchResult) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
 // QueryOpenOrders gets the open orders of a specific account
 func (bc backendClient) QueryOpenOrders(addrStr, product, side string, start, end, page, perPage int) (orders []types.Order,
 	err error) {
-	perPageNum, err := params.CheckQueryOrdersParams(addrStr, product, side, start, end, page, perPage)
-	if err != nil {
-		return
-	}
-
-	// field hideNoFill fixed by false
-	ordersParams := params.NewQueryOrderListParams(addrStr, product, side, page, perPageNum, int64(start), int64(end), false)
-	jsonBytes, err := bc.GetCodec().MarshalJSON(ordersParams)
-	if err != nil {
-		return orders, utils.ErrMarshalJSON(err.Error())
-	}
-
-	res, err := bc.Query(types.OpenOrdersPath, jsonBytes)
-	if err != nil {
-		return orders, utils.ErrClientQuery(err.Error())
-	}
+	orders, _, err = bc.QueryOpenOrdersWithPage(addrStr, product, side, start, end, page, perPage)
+	return
+}
 
-	if err = utils.UnmarshalListResponse(res, &orders); err != nil {
-		return orders, utils.ErrFilterDataFromListResponse("open orders", err.Error())
-	}
+// QueryOpenOrdersWithPage gets the open orders of a specific account with the pagination info
+func (bc backendClient) QueryOpenOrdersWithPage(addrStr, product, side string, start, end, page, perPage int) (
+	orders []types.Order, paramPage types.ParamPage, err error) {
+	return bc.queryOrders(types.OpenOrdersPath, "open orders", addrStr, product, side, start, end, page, perPage)
+}
 
+// QueryAllOpenOrders gets the open orders of a specific account over all pages
+// NOTE: the pages are fetched concurrently. A positive limit caps the number of the orders returned
+func (bc backendClient) QueryAllOpenOrders(addrStr, product, side string, start, end, limit int) (orders []types.Order,
+	err error) {
+	err = queryAllPages(&orders, limit, func(page, perPage int, ptr interface{}) (types.ParamPage, error) {
+		pageOrders, paramPage, err := bc.QueryOpenOrdersWithPage(addrStr, product, side, start, end, page, perPage)
+		*ptr.(*[]types.Order) = pageOrders
+		return paramPage, err
+	})
 	return
 }
 
+// ForEachOpenOrder calls fn with the open orders of a specific account in order, fetching the pages one by one. The
+// iteration stops once fn returns false
+func (bc backendClient) ForEachOpenOrder(addrStr, product, side string, start, end int, fn func(types.Order) bool) error {
+	var orders []types.Order
+	return forEachPage(&orders, func(page, perPage int, ptr interface{}) (types.ParamPage, error) {
+		pageOrders, paramPage, err := bc.QueryOpenOrdersWithPage(addrStr, product, side, start, end, page, perPage)
+		*ptr.(*[]types.Order) = pageOrders
+		return paramPage, err
+	}, func() bool {
+		for _, order := range orders {
+			if !fn(order) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
 // QueryClosedOrders gets the closed orders of a specific account
 func (bc backendClient) QueryClosedOrders(addrStr, product, side string, start, end, page, perPage int) (orders []types.Order,
 	err error) {
-	perPageNum, err := params.CheckQueryOrdersParams(addrStr, product, side, start, end, page, perPage)
-	if err != nil {
-		return
-	}
-
-	// field hideNoFill fixed by false
-	ordersParams := params.NewQueryOrderListParams(addrStr, product, side, page, perPageNum, int64(start), int64(end), false)
-	jsonBytes, err := bc.GetCodec().MarshalJSON(ordersParams)
-	if err != nil {
-		return orders, utils.ErrMarshalJSON(err.Error())
-	}
-
-	res, err := bc.Query(types.ClosedOrdersPath, jsonBytes)
-	if err != nil {
-		return orders, utils.ErrClientQuery(err.Error())
-	}
+	orders, _, err = bc.QueryClosedOrdersWithPage(addrStr, product, side, start, end, page, perPage)
+	return
+}
 
-	if err = utils.UnmarshalListResponse(res, &orders); err != nil {
-		return orders, utils.ErrFilterDataFromListResponse("closed orders", err.Error())
-	}
+// QueryClosedOrdersWithPage gets the closed orders of a specific account with the pagination info
+func (bc backendClient) QueryClosedOrdersWithPage(addrStr, product, side string, start, end, page, perPage int) (
+	orders []types.Order, paramPage types.ParamPage, err error) {
+	return bc.queryOrders(types.ClosedOrdersPath, "closed orders", addrStr, product, side, start, end, page, perPage)
+}
 
+// QueryAllClosedOrders gets the closed orders of a specific account over all pages
+// NOTE: the pages are fetched concurrently. A positive limit caps the number of the orders returned
+func (bc backendClient) QueryAllClosedOrders(addrStr, product, side string, start, end, limit int) (orders []types.Order,
+	err error) {
+	err = queryAllPages(&orders, limit, func(page, perPage int, ptr interface{}) (types.ParamPage, error) {
+		pageOrders, paramPage, err := bc.QueryClosedOrdersWithPage(addrStr, product, side, start, end, page, perPage)
+		*ptr.(*[]types.Order) = pageOrders
+		return paramPage, err
+	})
 	return
 }
 
+// ForEachClosedOrder calls fn with the closed orders of a specific account in order, fetching the pages one by one. The
+// iteration stops once fn returns false
+func (bc backendClient) ForEachClosedOrder(addrStr, product, side string, start, end int, fn func(types.Order) bool) error {
+	var orders []types.Order
+	return forEachPage(&orders, func(page, perPage int, ptr interface{}) (types.ParamPage, error) {
+		pageOrders, paramPage, err := bc.QueryClosedOrdersWithPage(addrStr, product, side, start, end, page, perPage)
+		*ptr.(*[]types.Order) = pageOrders
+		return paramPage, err
+	}, func() bool {
+		for _, order := range orders {
+			if !fn(order) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
 // QueryDeals gets the deals info of a specific account
 func (bc backendClient) QueryDeals(addrStr, product, side string, start, end, page, perPage int) (deals []types.Deal, err error) {
+	deals, _, err = bc.QueryDealsWithPage(addrStr, product, side, start, end, page, perPage)
+	return
+}
+
+// QueryDealsWithPage gets the deals info of a specific account with the pagination info
+func (bc backendClient) QueryDealsWithPage(addrStr, product, side string, start, end, page, perPage int) (
+	deals []types.Deal, paramPage types.ParamPage, err error) {
 	perPageNum, err := params.CheckQueryOrdersParams(addrStr, product, side, start, end, page, perPage)
 	if err != nil {
 		return
 	}
 
 	dealsParams := params.NewQueryDealsParams(addrStr, product, int64(start), int64(end), page, perPageNum, side)
-	jsonBytes, err := bc.GetCodec().MarshalJSON(dealsParams)
-	if err != nil {
-		return deals, utils.ErrMarshalJSON(err.Error())
-	}
-
-	res, err := bc.Query(types.DealsPath, jsonBytes)
-	if err != nil {
-		return deals, utils.ErrClientQuery(err.Error())
-	}
-
-	if err = utils.UnmarshalListResponse(res, &deals); err != nil {
-		return deals, utils.ErrUnmarshalJSON(err.Error())
-	}
+	paramPage, err = bc.queryList(types.DealsPath, "deals", dealsParams, &deals, errUnmarshalJSON)
+	return
+}
 
+// QueryAllDeals gets the deals info of a specific account over all pages
+// NOTE: the pages are fetched concurrently. A positive limit caps the number of the deals returned
+func (bc backendClient) QueryAllDeals(addrStr, product, side string, start, end, limit int) (deals []types.Deal,
+	err error) {
+	err = queryAllPages(&deals, limit, func(page, perPage int, ptr interface{}) (types.ParamPage, error) {
+		pageDeals, paramPage, err := bc.QueryDealsWithPage(addrStr, product, side, start, end, page, perPage)
+		*ptr.(*[]types.Deal) = pageDeals
+		return paramPage, err
+	})
 	return
 }
 
+// ForEachDeal calls fn with the deals of a specific account in order, fetching the pages one by one. The iteration
+// stops once fn returns false
+func (bc backendClient) ForEachDeal(addrStr, product, side string, start, end int, fn func(types.Deal) bool) error {
+	var deals []types.Deal
+	return forEachPage(&deals, func(page, perPage int, ptr interface{}) (types.ParamPage, error) {
+		pageDeals, paramPage, err := bc.QueryDealsWithPage(addrStr, product, side, start, end, page, perPage)
+		*ptr.(*[]types.Deal) = pageDeals
+		return paramPage, err
+	}, func() bool {
+		for _, deal := range deals {
+			if !fn(deal) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
 // QueryTransactions gets the transactions of a specific account
 func (bc backendClient) QueryTransactions(addrStr string, typeCode, start, end, page, perPage int) (transactions []types.Transaction, err error) {
+	transactions, _, err = bc.QueryTransactionsWithPage(addrStr, typeCode, start, end, page, perPage)
+	return
+}
+
+// QueryTransactionsWithPage gets the transactions of a specific account with the pagination info
+func (bc backendClient) QueryTransactionsWithPage(addrStr string, typeCode, start, end, page, perPage int) (
+	transactions []types.Transaction, paramPage types.ParamPage, err error) {
 	perPageNum, err := params.CheckQueryTransactionsParams(addrStr, typeCode, start, end, page, perPage)
 	if err != nil {
 		return
 	}
 
 	transactionsParams := params.NewQueryTxListParams(addrStr, int64(typeCode), int64(start), int64(end), page, perPageNum)
-	jsonBytes, err := bc.GetCodec().MarshalJSON(transactionsParams)
+	paramPage, err = bc.queryList(types.TransactionsPath, "transactions", transactionsParams, &transactions, utils.ErrFilterDataFromListResponse)
+	return
+}
+
+// QueryAllTransactions gets the transactions of a specific account over all pages
+// NOTE: the pages are fetched concurrently. A positive limit caps the number of the transactions returned
+func (bc backendClient) QueryAllTransactions(addrStr string, typeCode, start, end, limit int) (
+	transactions []types.Transaction, err error) {
+	err = queryAllPages(&transactions, limit, func(page, perPage int, ptr interface{}) (types.ParamPage, error) {
+		pageTransactions, paramPage, err := bc.QueryTransactionsWithPage(addrStr, typeCode, start, end, page, perPage)
+		*ptr.(*[]types.Transaction) = pageTransactions
+		return paramPage, err
+	})
+	return
+}
+
+// ForEachTransaction calls fn with the transactions of a specific account in order, fetching the pages one by one. The
+// iteration stops once fn returns false
+func (bc backendClient) ForEachTransaction(addrStr string, typeCode, start, end int,
+	fn func(types.Transaction) bool) error {
+	var transactions []types.Transaction
+	return forEachPage(&transactions, func(page, perPage int, ptr interface{}) (types.ParamPage, error) {
+		pageTransactions, paramPage, err := bc.QueryTransactionsWithPage(addrStr, typeCode, start, end, page, perPage)
+		*ptr.(*[]types.Transaction) = pageTransactions
+		return paramPage, err
+	}, func() bool {
+		for _, transaction := range transactions {
+			if !fn(transaction) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func (bc backendClient) queryOrders(path, kind, addrStr, product, side string, start, end, page, perPage int) (
+	orders []types.Order, paramPage types.ParamPage, err error) {
+	perPageNum, err := params.CheckQueryOrdersParams(addrStr, product, side, start, end, page, perPage)
+	if err != nil {
+		return
+	}
+
+	// field hideNoFill fixed by false
+	ordersParams := params.NewQueryOrderListParams(addrStr, product, side, page, perPageNum, int64(start), int64(end), false)
+	paramPage, err = bc.queryList(path, kind, ordersParams, &orders, utils.ErrFilterDataFromListResponse)
+	return
+}
+
+// queryList queries the list on the path. The decoding error is wrapped by wrap, while the error that backend responds
+// is kept as it is
+func (bc backendClient) queryList(path, kind string, queryParams, ptr interface{},
+	wrap func(kind, errMsg string) error) (paramPage types.ParamPage, err error) {
+	jsonBytes, err := bc.GetCodec().MarshalJSON(queryParams)
 	if err != nil {
-		return transactions, utils.ErrMarshalJSON(err.Error())
+		return paramPage, utils.ErrMarshalJSON(err.Error())
 	}
 
-	res, err := bc.Query(types.TransactionsPath, jsonBytes)
+	res, err := bc.Query(path, jsonBytes)
 	if err != nil {
-		return transactions, utils.ErrClientQuery(err.Error())
+		return paramPage, utils.ErrClientQuery(err.Error())
 	}
 
-	if err = utils.UnmarshalListResponse(res, &transactions); err != nil {
-		return transactions, utils.ErrFilterDataFromListResponse("transactions", err.Error())
+	if paramPage, err = utils.UnmarshalListResponse(res, ptr); err != nil {
+		return paramPage, filterErr(err, wrap, kind)
 	}
 
 	return
 }
+
+// errUnmarshalJSON wraps the decoding error of deals as utils.ErrUnmarshalJSON, which the callers may match on
+func errUnmarshalJSON(_, errMsg string) error {
+	return utils.ErrUnmarshalJSON(errMsg)
+}
+
+// filterErr keeps the error that backend responds as it is for the callers to check the code
+func filterErr(err error, wrap func(kind, errMsg string) error, kind string) error {
+	if utils.IsBackendResponseError(err) {
+		return err
+	}
+
+	return wrap(kind, err.Error())
+}
@@ -1,7 +1,9 @@
 package backend
 
 import (
+	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -210,6 +212,7 @@ func TestBackendClient_QueryDeals(t *testing.T) {
 	mockCli.EXPECT().Query(types.DealsPath, cmn.HexBytes(queryBytes)).Return(expectedRet[1:], nil)
 	_, err = mockCli.Backend().QueryDeals(addr, product, side, start, end, page, perPage)
 	require.Error(t, err)
+	require.True(t, strings.HasPrefix(err.Error(), "failed. unmarshal JSON error"))
 
 }
 
@@ -470,3 +473,307 @@ func TestBackendClient_QueryTransactions(t *testing.T) {
 	_, err = mockCli.Backend().QueryTransactions(addr, txType, start, end, page, perPage)
 	require.Error(t, err)
 }
+
+func TestBackendClient_QueryOpenOrdersWithPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := sdk.NewClientConfig("testURL", "testChain", sdk.BroadcastBlock, "0.01okt", 200000)
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewBackendClient(mockCli.MockBaseClient))
+
+	start, end, page, perPage := 0, 0, 2, 30
+	expectedRet := mockCli.BuildBackendListResponseBytes([]types.Order{{OrderID: "ID0000000000-31"}}, page, perPage, 31)
+	expectedCdc := mockCli.GetCodec()
+
+	queryParams := params.NewQueryOrderListParams(addr, product, "BUY", page, perPage, int64(start), int64(end), false)
+	queryBytes := expectedCdc.MustMarshalJSON(queryParams)
+
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).Times(2)
+	mockCli.EXPECT().Query(types.OpenOrdersPath, cmn.HexBytes(queryBytes)).Return(expectedRet, nil)
+
+	openOrders, paramPage, err := mockCli.Backend().QueryOpenOrdersWithPage(addr, product, "BUY", start, end, page,
+		perPage)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(openOrders))
+	require.Equal(t, "ID0000000000-31", openOrders[0].OrderID)
+	require.Equal(t, page, paramPage.Page)
+	require.Equal(t, perPage, paramPage.PerPage)
+	require.Equal(t, 31, paramPage.Total)
+
+	mockCli.EXPECT().Query(types.OpenOrdersPath, cmn.HexBytes(queryBytes)).Return([]byte("bad bytes"), nil)
+	_, _, err = mockCli.Backend().QueryOpenOrdersWithPage(addr, product, "BUY", start, end, page, perPage)
+	require.Error(t, err)
+}
+
+func TestBackendClient_QueryAllDeals(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := sdk.NewClientConfig("testURL", "testChain", sdk.BroadcastBlock, "0.01okt", 200000)
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewBackendClient(mockCli.MockBaseClient))
+
+	expectedCdc := mockCli.GetCodec()
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).AnyTimes()
+
+	// 450 deals in 3 pages
+	total := 450
+	expectPage := func(page, perPage, total int, err error) {
+		var deals []types.Deal
+		for i := (page - 1) * perPage; i < page*perPage && i < total; i++ {
+			deals = append(deals, types.Deal{BlockHeight: int64(i)})
+		}
+		queryParams := params.NewQueryDealsParams(addr, product, 0, 0, page, perPage, "SELL")
+		mockCli.EXPECT().Query(types.DealsPath, cmn.HexBytes(expectedCdc.MustMarshalJSON(queryParams))).
+			Return(mockCli.BuildBackendListResponseBytes(deals, page, perPage, total), err)
+	}
+	for page := 1; page <= 3; page++ {
+		expectPage(page, params.PerPageMax, total, nil)
+	}
+
+	deals, err := mockCli.Backend().QueryAllDeals(addr, product, "SELL", 0, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, total, len(deals))
+	for i, deal := range deals {
+		require.Equal(t, int64(i), deal.BlockHeight)
+	}
+
+	// limit
+	expectPage(1, params.PerPageMax, total, nil)
+	expectPage(2, params.PerPageMax, total, nil)
+	deals, err = mockCli.Backend().QueryAllDeals(addr, product, "SELL", 0, 0, 250)
+	require.NoError(t, err)
+	require.Equal(t, 250, len(deals))
+	require.Equal(t, int64(249), deals[249].BlockHeight)
+
+	expectPage(1, 10, total, nil)
+	deals, err = mockCli.Backend().QueryAllDeals(addr, product, "SELL", 0, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 10, len(deals))
+
+	// no total info
+	expectPage(1, params.PerPageMax, 0, nil)
+	deals, err = mockCli.Backend().QueryAllDeals(addr, product, "SELL", 0, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(deals))
+
+	// error in a page
+	expectPage(1, params.PerPageMax, total, nil)
+	expectPage(2, params.PerPageMax, total, nil)
+	expectPage(3, params.PerPageMax, total, errors.New("default error"))
+	_, err = mockCli.Backend().QueryAllDeals(addr, product, "SELL", 0, 0, 0)
+	require.Error(t, err)
+
+	_, err = mockCli.Backend().QueryAllDeals(addr[1:], product, "SELL", 0, 0, 0)
+	require.Error(t, err)
+}
+
+func TestBackendClient_ForEachDeal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := sdk.NewClientConfig("testURL", "testChain", sdk.BroadcastBlock, "0.01okt", 200000)
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewBackendClient(mockCli.MockBaseClient))
+
+	expectedCdc := mockCli.GetCodec()
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).AnyTimes()
+
+	// 250 deals in 3 pages with the per page number cut down to 100 by the backend
+	total, backendPerPage := 250, 100
+	expectPage := func(page, perPage int, err error) {
+		var deals []types.Deal
+		for i := (page - 1) * backendPerPage; i < page*backendPerPage && i < total; i++ {
+			deals = append(deals, types.Deal{BlockHeight: int64(i)})
+		}
+		queryParams := params.NewQueryDealsParams(addr, product, 0, 0, page, perPage, "SELL")
+		mockCli.EXPECT().Query(types.DealsPath, cmn.HexBytes(expectedCdc.MustMarshalJSON(queryParams))).
+			Return(mockCli.BuildBackendListResponseBytes(deals, page, backendPerPage, total), err)
+	}
+	expectPage(1, params.PerPageMax, nil)
+	expectPage(2, backendPerPage, nil)
+	expectPage(3, backendPerPage, nil)
+
+	var heights []int64
+	err = mockCli.Backend().ForEachDeal(addr, product, "SELL", 0, 0, func(deal types.Deal) bool {
+		heights = append(heights, deal.BlockHeight)
+		return true
+	})
+	require.NoError(t, err)
+	require.Equal(t, total, len(heights))
+	for i, height := range heights {
+		require.Equal(t, int64(i), height)
+	}
+
+	// stop in the second page without fetching the third one
+	expectPage(1, params.PerPageMax, nil)
+	expectPage(2, backendPerPage, nil)
+	heights = nil
+	err = mockCli.Backend().ForEachDeal(addr, product, "SELL", 0, 0, func(deal types.Deal) bool {
+		heights = append(heights, deal.BlockHeight)
+		return deal.BlockHeight < 149
+	})
+	require.NoError(t, err)
+	require.Equal(t, 150, len(heights))
+
+	// error in a page
+	expectPage(1, params.PerPageMax, nil)
+	expectPage(2, backendPerPage, errors.New("default error"))
+	err = mockCli.Backend().ForEachDeal(addr, product, "SELL", 0, 0, func(types.Deal) bool { return true })
+	require.Error(t, err)
+}
+
+func TestBackendClient_QueryCandlesDec(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := sdk.NewClientConfig("testURL", "testChain", sdk.BroadcastBlock, "0.01okt", 200000)
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewBackendClient(mockCli.MockBaseClient))
+
+	mockCandles := [][]string{
+		{"2020-01-01T00:00:00.000Z", "1.024", "4.096", "0.512", "2.048", "123456789.12345678"},
+		{"1577836860000", "2.048", "2.048", "2.048", "2.048", "1e-5"},
+	}
+	granularity, size := 60, 2
+
+	expectedCdc := mockCli.GetCodec()
+	queryBytes := expectedCdc.MustMarshalJSON(params.NewQueryKlinesParams(product, granularity, size))
+
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).Times(2)
+	mockCli.EXPECT().Query(types.CandlesPath, cmn.HexBytes(queryBytes)).
+		Return(mockCli.BuildBackendCandlesBytes(mockCandles), nil)
+
+	candles, err := mockCli.Backend().QueryCandlesDec(product, granularity, size)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(candles))
+	require.Equal(t, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), candles[0].Timestamp)
+	require.Equal(t, sdk.MustNewDecFromStr("1.024"), candles[0].Open)
+	require.Equal(t, sdk.MustNewDecFromStr("4.096"), candles[0].High)
+	require.Equal(t, sdk.MustNewDecFromStr("0.512"), candles[0].Low)
+	require.Equal(t, sdk.MustNewDecFromStr("2.048"), candles[0].Close)
+	require.Equal(t, sdk.MustNewDecFromStr("123456789.12345678"), candles[0].Volume)
+	require.Equal(t, time.Date(2020, 1, 1, 0, 1, 0, 0, time.UTC), candles[1].Timestamp)
+	require.Equal(t, sdk.MustNewDecFromStr("0.00001"), candles[1].Volume)
+
+	// backward-compatible raw format
+	rawCandle := candles[0].Strings()
+	require.Equal(t, "2020-01-01T00:00:00Z", rawCandle[0])
+	candle, err := types.ParseCandle(rawCandle)
+	require.NoError(t, err)
+	require.Equal(t, candles[0], candle)
+
+	// bad candles
+	mockCli.EXPECT().Query(types.CandlesPath, cmn.HexBytes(queryBytes)).
+		Return(mockCli.BuildBackendCandlesBytes([][]string{{"1.024", "2.048", "4.096", "8.192"}}), nil)
+	_, err = mockCli.Backend().QueryCandlesDec(product, granularity, size)
+	require.Error(t, err)
+
+	_, err = types.ParseCandles([][]string{{"yesterday", "1.024", "4.096", "0.512", "2.048", "1"}})
+	require.Error(t, err)
+	_, err = types.ParseCandles([][]string{{"1577836860000", "1.024", "4.096", "0.512", "2.048.1", "1"}})
+	require.Error(t, err)
+}
+
+func TestBackendClient_QueryTickersDec(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := sdk.NewClientConfig("testURL", "testChain", sdk.BroadcastBlock, "0.01okt", 200000)
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewBackendClient(mockCli.MockBaseClient))
+
+	timestamp := "2020-01-01T00:00:00.000Z"
+	expectedRet := mockCli.BuildBackendTickersBytes(product, product, timestamp, "1.024", "2.048", "4.096", "0.512",
+		"2.048", "123456789.12345678", "-0.5")
+	expectedCdc := mockCli.GetCodec()
+	queryBytes := expectedCdc.MustMarshalJSON(params.NewQueryTickerParams(product, 10, true))
+
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).Times(2)
+	mockCli.EXPECT().Query(types.TickersPath, cmn.HexBytes(queryBytes)).Return(expectedRet, nil)
+
+	tickers, err := mockCli.Backend().QueryTickersDec(product, 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(tickers))
+	require.Equal(t, product, tickers[0].Product)
+	require.Equal(t, timestamp, tickers[0].Timestamp)
+	require.Equal(t, sdk.MustNewDecFromStr("1.024"), tickers[0].Open)
+	require.Equal(t, sdk.MustNewDecFromStr("2.048"), tickers[0].Close)
+	require.Equal(t, sdk.MustNewDecFromStr("123456789.12345678"), tickers[0].Volume)
+	require.Equal(t, sdk.MustNewDecFromStr("-0.5"), tickers[0].Change)
+
+	ticker := tickers[0].ToTicker()
+	require.Equal(t, "123456789.12345678", ticker.Volume)
+	tickerDec, err := ticker.ToDec()
+	require.NoError(t, err)
+	require.Equal(t, tickers[0], tickerDec)
+
+	mockCli.EXPECT().Query(types.TickersPath, cmn.HexBytes(queryBytes)).Return(mockCli.BuildBackendTickersBytes(
+		product, product, timestamp, "1.024", "2.048", "4.096", "0.512", "2.048", "1.2.3", "-0.5"), nil)
+	_, err = mockCli.Backend().QueryTickersDec(product, 10)
+	require.Error(t, err)
+}
+
+func TestBackendClient_QueryRecentTxRecordDec(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := sdk.NewClientConfig("testURL", "testChain", sdk.BroadcastBlock, "0.01okt", 200000)
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewBackendClient(mockCli.MockBaseClient))
+
+	start, end, page, perPage := 0, 0, 1, 30
+	// the price with 18 significant digits loses precision in float64
+	expectedRet := []byte(`{"code":0,"msg":"","detail_msg":"","data":{"data":[{"timestamp":1577836800000,` +
+		`"block_height":1024,"product":"btc-000_okt","price":1234567890.12345678,"volume":123456789.12345678}],` +
+		`"param_page":{"page":1,"per_page":30,"total":1}}}`)
+	expectedCdc := mockCli.GetCodec()
+	queryBytes := expectedCdc.MustMarshalJSON(params.NewQueryMatchParams(product, int64(start), int64(end), page,
+		perPage))
+
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).Times(2)
+	mockCli.EXPECT().Query(types.RecentTxRecordPath, cmn.HexBytes(queryBytes)).Return(expectedRet, nil).Times(2)
+
+	record, err := mockCli.Backend().QueryRecentTxRecordDec(product, start, end, page, perPage)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(record))
+	require.Equal(t, int64(1577836800000), record[0].Timestamp)
+	require.Equal(t, int64(1024), record[0].BlockHeight)
+	require.Equal(t, product, record[0].Product)
+	require.Equal(t, sdk.MustNewDecFromStr("1234567890.12345678"), record[0].Price)
+	require.Equal(t, sdk.MustNewDecFromStr("123456789.12345678"), record[0].Quantity)
+
+	floatRecord, err := mockCli.Backend().QueryRecentTxRecord(product, start, end, page, perPage)
+	require.NoError(t, err)
+	require.Equal(t, floatRecord[0], record[0].ToMatchResult())
+
+	_, err = mockCli.Backend().QueryRecentTxRecordDec("", start, end, page, perPage)
+	require.Error(t, err)
+
+	// missing volume
+	var matchResult types.MatchResultDec
+	require.Error(t, json.Unmarshal([]byte(`{"timestamp":1577836800000,"price":1.024}`), &matchResult))
+}
+
+func TestBackendClient_ResponseError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := sdk.NewClientConfig("testURL", "testChain", sdk.BroadcastBlock, "0.01okt", 200000)
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewBackendClient(mockCli.MockBaseClient))
+
+	expectedCdc := mockCli.GetCodec()
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).AnyTimes()
+	mockCli.EXPECT().Query(types.TickersPath, gomock.Any()).
+		Return([]byte(`{"code":30001,"msg":"invalid params","detail_msg":"product is not found","data":null}`), nil)
+	mockCli.EXPECT().Query(types.DealsPath, gomock.Any()).
+		Return([]byte(`{"code":30002,"msg":"server busy","detail_msg":"","data":{"data":null}}`), nil)
+
+	_, err = mockCli.Backend().QueryTickers(product)
+	require.Equal(t, types.ResponseError{Code: 30001, Msg: "invalid params", DetailMsg: "product is not found"}, err)
+
+	_, err = mockCli.Backend().QueryDeals(addr, product, "BUY", 0, 0, 1, 10)
+	require.Equal(t, types.ResponseError{Code: 30002, Msg: "server busy"}, err)
+}
@@ -0,0 +1,214 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	sdk "github.com/okex/okchain-go-sdk/types"
+)
+
+// Candle - structure of a candle in the kline data
+type Candle struct {
+	Timestamp time.Time `json:"timestamp"`
+	Open      sdk.Dec   `json:"open"`
+	High      sdk.Dec   `json:"high"`
+	Low       sdk.Dec   `json:"low"`
+	Close     sdk.Dec   `json:"close"`
+	Volume    sdk.Dec   `json:"volume"`
+}
+
+// ParseCandle converts a raw candle in the order of [timestamp, open, high, low, close, volume] to Candle
+func ParseCandle(rawCandle []string) (candle Candle, err error) {
+	if len(rawCandle) != 6 {
+		return candle, fmt.Errorf("failed. a raw candle should be with 6 fields but got %d", len(rawCandle))
+	}
+
+	if candle.Timestamp, err = parseTimestamp(rawCandle[0]); err != nil {
+		return
+	}
+
+	decs := make([]sdk.Dec, 5)
+	for i := range decs {
+		if decs[i], err = parseDecStr(rawCandle[i+1]); err != nil {
+			return
+		}
+	}
+
+	candle.Open, candle.High, candle.Low, candle.Close, candle.Volume = decs[0], decs[1], decs[2], decs[3], decs[4]
+	return
+}
+
+// ParseCandles converts raw candles to Candles
+func ParseCandles(rawCandles [][]string) (candles []Candle, err error) {
+	candles = make([]Candle, len(rawCandles))
+	for i, rawCandle := range rawCandles {
+		if candles[i], err = ParseCandle(rawCandle); err != nil {
+			return nil, err
+		}
+	}
+
+	return
+}
+
+// Strings converts the candle back to the raw format that QueryCandles returns
+func (c Candle) Strings() []string {
+	return []string{c.Timestamp.UTC().Format(time.RFC3339Nano), c.Open.String(), c.High.String(), c.Low.String(),
+		c.Close.String(), c.Volume.String()}
+}
+
+// TickerDec - structure of ticker's detail data with the prices and volume in sdk.Dec
+type TickerDec struct {
+	Symbol    string  `json:"symbol"`
+	Product   string  `json:"product"`
+	Timestamp string  `json:"timestamp"`
+	Open      sdk.Dec `json:"open"`
+	Close     sdk.Dec `json:"close"`
+	High      sdk.Dec `json:"high"`
+	Low       sdk.Dec `json:"low"`
+	Price     sdk.Dec `json:"price"`
+	Volume    sdk.Dec `json:"volume"`
+	Change    sdk.Dec `json:"change"`
+}
+
+// ToDec converts the ticker to TickerDec
+func (t Ticker) ToDec() (tickerDec TickerDec, err error) {
+	tickerDec = TickerDec{
+		Symbol:    t.Symbol,
+		Product:   t.Product,
+		Timestamp: t.Timestamp,
+	}
+
+	fields := []struct {
+		ptr *sdk.Dec
+		str string
+	}{
+		{&tickerDec.Open, t.Open},
+		{&tickerDec.Close, t.Close},
+		{&tickerDec.High, t.High},
+		{&tickerDec.Low, t.Low},
+		{&tickerDec.Price, t.Price},
+		{&tickerDec.Volume, t.Volume},
+		{&tickerDec.Change, t.Change},
+	}
+	for _, field := range fields {
+		if *field.ptr, err = parseDecStr(field.str); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// ToTicker converts the TickerDec back to Ticker
+func (td TickerDec) ToTicker() Ticker {
+	return Ticker{
+		Symbol:    td.Symbol,
+		Product:   td.Product,
+		Timestamp: td.Timestamp,
+		Open:      td.Open.String(),
+		Close:     td.Close.String(),
+		High:      td.High.String(),
+		Low:       td.Low.String(),
+		Price:     td.Price.String(),
+		Volume:    td.Volume.String(),
+		Change:    td.Change.String(),
+	}
+}
+
+// MatchResultDec - structure for recent tx record with the price and quantity in sdk.Dec
+type MatchResultDec struct {
+	Timestamp   int64   `json:"timestamp"`
+	BlockHeight int64   `json:"block_height"`
+	Product     string  `json:"product"`
+	Price       sdk.Dec `json:"price"`
+	Quantity    sdk.Dec `json:"volume"`
+}
+
+// UnmarshalJSON decodes the price and quantity from the raw JSON numbers without the loss of float64
+func (mrd *MatchResultDec) UnmarshalJSON(bz []byte) error {
+	var raw struct {
+		Timestamp   int64       `json:"timestamp"`
+		BlockHeight int64       `json:"block_height"`
+		Product     string      `json:"product"`
+		Price       json.Number `json:"price"`
+		Quantity    json.Number `json:"volume"`
+	}
+	if err := json.Unmarshal(bz, &raw); err != nil {
+		return err
+	}
+
+	price, err := parseDecStr(raw.Price.String())
+	if err != nil {
+		return err
+	}
+
+	quantity, err := parseDecStr(raw.Quantity.String())
+	if err != nil {
+		return err
+	}
+
+	*mrd = MatchResultDec{
+		Timestamp:   raw.Timestamp,
+		BlockHeight: raw.BlockHeight,
+		Product:     raw.Product,
+		Price:       price,
+		Quantity:    quantity,
+	}
+	return nil
+}
+
+// ToMatchResult converts the MatchResultDec back to MatchResult
+func (mrd MatchResultDec) ToMatchResult() MatchResult {
+	price, _ := strconv.ParseFloat(mrd.Price.String(), 64)
+	quantity, _ := strconv.ParseFloat(mrd.Quantity.String(), 64)
+	return MatchResult{
+		Timestamp:   mrd.Timestamp,
+		BlockHeight: mrd.BlockHeight,
+		Product:     mrd.Product,
+		Price:       price,
+		Quantity:    quantity,
+	}
+}
+
+// parseDecStr parses a decimal string, including the one in the exponent notation, to sdk.Dec. An empty string from a
+// missing field is an error
+func parseDecStr(str string) (sdk.Dec, error) {
+	str = strings.TrimSpace(str)
+	if len(str) == 0 {
+		return sdk.Dec{}, errors.New("failed. empty decimal string")
+	}
+
+	if strings.ContainsAny(str, "eE") {
+		f, _, err := big.ParseFloat(str, 10, 256, big.ToNearestEven)
+		if err != nil {
+			return sdk.Dec{}, fmt.Errorf("failed. parse decimal %s error: %s", str, err)
+		}
+		str = strings.TrimRight(strings.TrimRight(f.Text('f', sdk.Precision), "0"), ".")
+	}
+
+	dec, err := sdk.NewDecFromStr(str)
+	if err != nil {
+		return sdk.Dec{}, fmt.Errorf("failed. parse decimal %s error: %s", str, err)
+	}
+
+	return dec, nil
+}
+
+// parseTimestamp parses a timestamp in RFC3339 or in unix milliseconds
+func parseTimestamp(str string) (time.Time, error) {
+	if ms, err := strconv.ParseInt(str, 10, 64); err == nil {
+		return time.Unix(0, ms*int64(time.Millisecond)).UTC(), nil
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, str)
+	if err != nil {
+		return t, fmt.Errorf("failed. parse timestamp %s error: %s", str, err)
+	}
+
+	return t.UTC(), nil
+}
@@ -1,5 +1,7 @@
 package types
 
+import "fmt"
+
 // const
 const (
 	ModuleName = "backend"
@@ -51,6 +53,19 @@ type ParamPage struct {
 	Total   int `json:"total"`
 }
 
+// TotalPages returns the number of pages over the total items
+func (pp ParamPage) TotalPages() int {
+	if pp.PerPage <= 0 {
+		return 0
+	}
+	return (pp.Total + pp.PerPage - 1) / pp.PerPage
+}
+
+// HasMore shows whether there are pages after the current one
+func (pp ParamPage) HasMore() bool {
+	return pp.Page < pp.TotalPages()
+}
+
 // ListDataRes - structure of list data in the list response
 type ListDataRes struct {
 	Data      interface{} `json:"data"`
@@ -65,6 +80,21 @@ type ListResponse struct {
 	Data      ListDataRes `json:"data"`
 }
 
+// ResponseError - structure of the error that backend responds with a non-zero code
+type ResponseError struct {
+	Code      int    `json:"code"`
+	Msg       string `json:"msg"`
+	DetailMsg string `json:"detail_msg"`
+}
+
+// Error implements the error interface
+func (re ResponseError) Error() string {
+	if len(re.DetailMsg) == 0 {
+		return fmt.Sprintf("failed. backend responds with code %d: %s", re.Code, re.Msg)
+	}
+	return fmt.Sprintf("failed. backend responds with code %d: %s, detail: %s", re.Code, re.Msg, re.DetailMsg)
+}
+
 // Order - structure of order query result
 type Order struct {
 	TxHash         string `json:"txhash"`
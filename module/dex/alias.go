@@ -10,4 +10,16 @@ const (
 type (
 	// TokenPair is the type alias of the one under dex/types
 	TokenPair = types.TokenPair
+	// ListEvent is the type alias of the one under dex/types
+	ListEvent = types.ListEvent
+	// DepositEvent is the type alias of the one under dex/types
+	DepositEvent = types.DepositEvent
+	// DepositInfo is the type alias of the one under dex/types
+	DepositInfo = types.DepositInfo
+	// DepositRank is the type alias of the one under dex/types
+	DepositRank = types.DepositRank
+	// WithdrawInfo is the type alias of the one under dex/types
+	WithdrawInfo = types.WithdrawInfo
+	// Params is the type alias of the one under dex/types
+	Params = types.Params
 )
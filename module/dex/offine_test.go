@@ -3,23 +3,34 @@ package dex
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/golang/mock/gomock"
 	"github.com/okex/okchain-go-sdk/mocks"
 	"github.com/okex/okchain-go-sdk/module"
+	"github.com/okex/okchain-go-sdk/module/dex/types"
 	sdk "github.com/okex/okchain-go-sdk/types"
 	"github.com/okex/okchain-go-sdk/utils"
 	"github.com/stretchr/testify/require"
 )
 
 const (
-	unsignedPath           = "./unsignedTx.json"
-	signedPath             = "./signedTx.json"
 	expectedUnsignedTxJSON = `{"type":"cosmos-sdk/StdTx","value":{"msg":[{"type":"okchain/dex/MsgTransferTradingPairOwnership","value":{"from_address":"okchain1dcsxvxgj374dv3wt9szflf9nz6342juzzkjnlz","to_address":"okchain1wux20ku36ntgtxpgm7my9863xy3fqs0xgh66d7","product":"btc-000_okt","to_signature":{"pub_key":null,"signature":null}}}],"fee":{"amount":[{"denom":"okt","amount":"0.01000000"}],"gas":"200000"},"signatures":null,"memo":"my memo"}}`
 	expectedSignedTxJSON   = `{"type":"cosmos-sdk/StdTx","value":{"msg":[{"type":"okchain/dex/MsgTransferTradingPairOwnership","value":{"from_address":"okchain1dcsxvxgj374dv3wt9szflf9nz6342juzzkjnlz","to_address":"okchain1wux20ku36ntgtxpgm7my9863xy3fqs0xgh66d7","product":"btc-000_okt","to_signature":{"pub_key":{"type":"tendermint/PubKeySecp256k1","value":"AgNf7LwXZdAuTZs7XAY7lbdVaOvQlpGVyc2TV0QrSgj+"},"signature":"/3yLlZ96fDy97CRC/6kvooNJveVcANUTu6dbAEaaiipwKrpeUeNFoMFsBgxvM/+dWf2PckTezHRLKwk/ExQ+gA=="}}}],"fee":{"amount":[{"denom":"okt","amount":"0.01000000"}],"gas":"200000"},"signatures":null,"memo":"my memo"}}`
 )
 
+// txPaths returns the paths of the unsigned and signed tx files in a new temporary directory
+func txPaths(t *testing.T, prefix string) (dir, unsignedPath, signedPath string) {
+	dir, err := ioutil.TempDir("", prefix)
+	require.NoError(t, err)
+	return dir, filepath.Join(dir, "unsignedTx.json"), filepath.Join(dir, "signedTx.json")
+}
+
 func TestDexClient_GenerateUnsignedTransferOwnershipTx(t *testing.T) {
+	dir, unsignedPath, _ := txPaths(t, "dex-offline")
+	defer os.RemoveAll(dir)
+
 	// make dex client
 	config, err := sdk.NewClientConfig("testURL", "testChain", sdk.BroadcastBlock, "0.01okt", 200000)
 	require.NoError(t, err)
@@ -48,13 +59,12 @@ func TestDexClient_GenerateUnsignedTransferOwnershipTx(t *testing.T) {
 	var expectedStdTx sdk.StdTx
 	cdc.MustUnmarshalJSON([]byte(expectedUnsignedTxJSON), &expectedStdTx)
 	require.Equal(t, expectedStdTx, stdTx)
-
-	// remove the temporary file: unsignedTx.json
-	err = os.Remove(unsignedPath)
-	require.NoError(t, err)
 }
 
 func TestDexClient_MultiSign(t *testing.T) {
+	dir, unsignedPath, signedPath := txPaths(t, "dex-offline")
+	defer os.RemoveAll(dir)
+
 	// set up unsignedTx.json
 	err := ioutil.WriteFile(unsignedPath, []byte(expectedUnsignedTxJSON), 0644)
 	require.NoError(t, err)
@@ -92,10 +102,63 @@ func TestDexClient_MultiSign(t *testing.T) {
 	var expectedStdTx sdk.StdTx
 	cdc.MustUnmarshalJSON([]byte(expectedSignedTxJSON), &expectedStdTx)
 	require.Equal(t, expectedStdTx, stdTx)
+}
+
+func TestDexClient_TransferOwnershipInMemory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := sdk.NewClientConfig("testURL", "testChain", sdk.BroadcastBlock, "0.01okt", 200000)
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewDexClient(mockCli.MockBaseClient))
+	cdc := mockCli.GetCodec()
+	dexClient := NewDexClient(module.NewBaseClient(cdc, &config))
+
+	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
+	require.NoError(t, err)
+	recInfo, _, err := utils.CreateAccountWithMnemo(recMnemonic, "bob", passWd)
+	require.NoError(t, err)
 
-	// remove the temporary files: unsignedTx.json and signedTx.json
-	err = os.Remove(unsignedPath)
+	unsignedTx, err := dexClient.BuildUnsignedTransferOwnershipTx(product, addr, recAddr, memo)
 	require.NoError(t, err)
-	err = os.Remove(signedPath)
+	require.Error(t, dexClient.ValidateTransferOwnershipTx(unsignedTx))
+
+	_, err = dexClient.BuildUnsignedTransferOwnershipTx(product, addr, addr, memo)
+	require.Error(t, err)
+
+	// the unsigned tx is passed to the receiver in json bytes
+	unsignedTx, err = utils.GetStdTxFromJSON(cdc, cdc.MustMarshalJSON(unsignedTx))
 	require.NoError(t, err)
+	_, err = utils.GetStdTxFromJSON(cdc, []byte("{"))
+	require.Error(t, err)
+
+	signedTx, err := dexClient.SignTransferOwnershipTx(recInfo, passWd, unsignedTx)
+	require.NoError(t, err)
+	require.NoError(t, dexClient.ValidateTransferOwnershipTx(signedTx))
+
+	_, err = dexClient.SignTransferOwnershipTx(fromInfo, passWd, unsignedTx)
+	require.Error(t, err)
+	_, err = dexClient.SignTransferOwnershipTx(nil, passWd, unsignedTx)
+	require.Error(t, err)
+	_, err = dexClient.SignTransferOwnershipTx(recInfo, passWd, sdk.StdTx{})
+	require.Error(t, err)
+
+	mockCli.EXPECT().BuildAndBroadcast(fromInfo.GetName(), passWd, memo, signedTx.Msgs, uint64(1),
+		uint64(2)).Return(mocks.DefaultMockSuccessTxResponse(), nil)
+	res, err := mockCli.Dex().BroadcastTransferOwnershipTx(fromInfo, passWd, signedTx, 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), res.Code)
+
+	_, err = mockCli.Dex().BroadcastTransferOwnershipTx(recInfo, passWd, signedTx, 1, 2)
+	require.Error(t, err)
+	_, err = mockCli.Dex().BroadcastTransferOwnershipTx(fromInfo, passWd, unsignedTx, 1, 2)
+	require.Error(t, err)
+
+	// tampered product makes the receiver's signature invalid
+	msg := signedTx.Msgs[0].(types.MsgTransferOwnership)
+	msg.Product = "eth-000_okt"
+	signedTx.Msgs = []sdk.Msg{msg}
+	require.Error(t, dexClient.ValidateTransferOwnershipTx(signedTx))
+	_, err = mockCli.Dex().BroadcastTransferOwnershipTx(fromInfo, passWd, signedTx, 1, 2)
+	require.Error(t, err)
 }
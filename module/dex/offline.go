@@ -3,65 +3,130 @@ package dex
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
+
 	"github.com/okex/okchain-go-sdk/module/dex/types"
 	sdk "github.com/okex/okchain-go-sdk/types"
 	"github.com/okex/okchain-go-sdk/types/crypto/keys"
 	"github.com/okex/okchain-go-sdk/types/tx"
 	"github.com/okex/okchain-go-sdk/utils"
-	"io/ioutil"
 )
 
 // GenerateUnsignedTransferOwnershipTx generates the unsigned transfer-ownership transaction offline
 func (dc dexClient) GenerateUnsignedTransferOwnershipTx(product, fromAddrStr, toAddrStr, memo, outputPath string) error {
+	stdTx, err := dc.BuildUnsignedTransferOwnershipTx(product, fromAddrStr, toAddrStr, memo)
+	if err != nil {
+		return err
+	}
+
+	return dc.writeStdTx(stdTx, outputPath)
+}
+
+// MultiSign appends signature to the unsigned tx file of transfer-ownership
+func (dc dexClient) MultiSign(fromInfo keys.Info, passWd, inputPath, outputPath string) error {
+	stdTx, err := utils.GetStdTxFromFile(dc.GetCodec(), inputPath)
+	if err != nil {
+		return err
+	}
+
+	signedTx, err := dc.SignTransferOwnershipTx(fromInfo, passWd, stdTx)
+	if err != nil {
+		return err
+	}
+
+	return dc.writeStdTx(signedTx, outputPath)
+}
+
+// BuildUnsignedTransferOwnershipTx builds the unsigned transfer-ownership transaction in memory. The tx is passed to the
+// receiver as it is or in json bytes by the codec, which are decoded by utils.GetStdTxFromJSON
+func (dc dexClient) BuildUnsignedTransferOwnershipTx(product, fromAddrStr, toAddrStr, memo string) (stdTx sdk.StdTx,
+	err error) {
 	if len(product) == 0 {
-		return errors.New("failed. empty product input")
+		return stdTx, errors.New("failed. empty product input")
 	}
 
 	fromAddr, err := sdk.AccAddressFromBech32(fromAddrStr)
 	if err != nil {
-		return fmt.Errorf("failed. parse Address [%s] error: %s", fromAddrStr, err)
+		return stdTx, fmt.Errorf("failed. parse Address [%s] error: %s", fromAddrStr, err)
 	}
 
 	toAddr, err := sdk.AccAddressFromBech32(toAddrStr)
 	if err != nil {
-		return fmt.Errorf("failed. parse Address [%s] error: %s", toAddr, err)
+		return stdTx, fmt.Errorf("failed. parse Address [%s] error: %s", toAddrStr, err)
 	}
 
-	msg := types.NewMsgTransferOwnership(fromAddr, toAddr, product)
-	jsonBytes, err := dc.GetCodec().MarshalJSON(dc.BuildUnsignedStdTxOffline([]sdk.Msg{msg}, memo))
-	if err != nil {
-		return err
+	if fromAddr.Equals(toAddr) {
+		return stdTx, errors.New("failed. the receiver is already the owner")
 	}
 
-	return ioutil.WriteFile(outputPath, jsonBytes, 0644)
+	msg := types.NewMsgTransferOwnership(fromAddr, toAddr, product)
+	return dc.BuildUnsignedStdTxOffline([]sdk.Msg{msg}, memo), nil
 }
 
-// MultiSign appends signature to the unsigned tx file of transfer-ownership
-func (dc dexClient) MultiSign(fromInfo keys.Info, passWd, inputPath, outputPath string) error {
-	stdTx, err := utils.GetStdTxFromFile(dc.GetCodec(), inputPath)
-	if err != nil {
-		return err
+// SignTransferOwnershipTx appends the receiver's signature to the unsigned transfer-ownership transaction in memory
+func (dc dexClient) SignTransferOwnershipTx(fromInfo keys.Info, passWd string, stdTx sdk.StdTx) (signedTx sdk.StdTx,
+	err error) {
+	if fromInfo == nil {
+		return signedTx, errors.New("failed. input invalid keys info")
 	}
 
-	if len(stdTx.Msgs) == 0 {
-		return errors.New("failed. msg is empty")
+	msg, err := getMsgTransferOwnership(stdTx)
+	if err != nil {
+		return
 	}
 
-	msg, ok := stdTx.Msgs[0].(types.MsgTransferOwnership)
-	if !ok {
-		return errors.New("failed. invalid msg type")
+	if !msg.ToAddress.Equals(fromInfo.GetAddress()) {
+		return signedTx, fmt.Errorf("failed. only the receiver %s is allowed to sign", msg.ToAddress)
 	}
 
+	// sign the msg without any receiver's signature
+	msg.ToSignature = sdk.StdSignature{}
 	signature, _, err := tx.Kb.Sign(fromInfo.GetName(), passWd, msg.GetSignBytes())
 	if err != nil {
-		return fmt.Errorf("failed. sign error: %s", err.Error())
+		return signedTx, fmt.Errorf("failed. sign error: %s", err.Error())
 	}
 
 	msg.ToSignature = sdk.NewStdSignature(fromInfo.GetPubKey(), signature)
-	jsonBytes, err := dc.GetCodec().MarshalJSON(dc.BuildUnsignedStdTxOffline([]sdk.Msg{msg}, stdTx.Memo))
+	return dc.BuildUnsignedStdTxOffline([]sdk.Msg{msg}, stdTx.Memo), nil
+}
+
+// ValidateTransferOwnershipTx checks that the transfer-ownership transaction has been signed by the receiver over the
+// msg itself, so that the owner won't broadcast a tx doomed to fail
+func (dc dexClient) ValidateTransferOwnershipTx(stdTx sdk.StdTx) error {
+	msg, err := getMsgTransferOwnership(stdTx)
+	if err != nil {
+		return err
+	}
+
+	return checkToSignature(msg)
+}
+
+func (dc dexClient) writeStdTx(stdTx sdk.StdTx, outputPath string) error {
+	jsonBytes, err := dc.GetCodec().MarshalJSON(stdTx)
 	if err != nil {
 		return err
 	}
 
 	return ioutil.WriteFile(outputPath, jsonBytes, 0644)
 }
+
+func getMsgTransferOwnership(stdTx sdk.StdTx) (msg types.MsgTransferOwnership, err error) {
+	rawMsg, err := utils.GetTransferOwnershipMsg(stdTx)
+	if err != nil {
+		return
+	}
+
+	msg, ok := rawMsg.(types.MsgTransferOwnership)
+	if !ok {
+		return msg, errors.New("failed. invalid msg type")
+	}
+
+	return
+}
+
+// checkToSignature verifies that the receiver's signature in the msg is signed by the receiver over the msg itself
+func checkToSignature(msg types.MsgTransferOwnership) error {
+	toSig := msg.ToSignature
+	msg.ToSignature = sdk.StdSignature{}
+	return utils.CheckToSignature(msg.ToAddress, toSig, msg.GetSignBytes())
+}
@@ -1,6 +1,9 @@
 package dex
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/okex/okchain-go-sdk/module/dex/types"
 	"github.com/okex/okchain-go-sdk/types/params"
 	"github.com/okex/okchain-go-sdk/utils"
@@ -29,3 +32,78 @@ func (dc dexClient) QueryProducts(ownerAddr string, page, perPage int) (tokenPai
 
 	return
 }
+
+// QueryDeposits gets the deposits on the products owned by an address
+func (dc dexClient) QueryDeposits(ownerAddr string, page, perPage int) (deposits []types.DepositInfo, err error) {
+	if len(ownerAddr) == 0 {
+		return deposits, errors.New("failed. empty owner address")
+	}
+
+	queryParams, err := params.NewQueryDexInfoParams(ownerAddr, page, perPage)
+	if err != nil {
+		return
+	}
+
+	jsonBytes, err := dc.GetCodec().MarshalJSON(queryParams)
+	if err != nil {
+		return deposits, utils.ErrMarshalJSON(err.Error())
+	}
+
+	res, err := dc.Query(types.DepositsPath, jsonBytes)
+	if err != nil {
+		return deposits, utils.ErrClientQuery(err.Error())
+	}
+
+	if err = dc.GetCodec().UnmarshalJSON(res, &deposits); err != nil {
+		return deposits, utils.ErrUnmarshalJSON(err.Error())
+	}
+
+	return
+}
+
+// QueryDepositRanking gets the ranks of all the products in the match priority decided by their deposits
+func (dc dexClient) QueryDepositRanking() (ranks []types.DepositRank, err error) {
+	res, err := dc.Query(types.MatchOrderPath, nil)
+	if err != nil {
+		return ranks, utils.ErrClientQuery(err.Error())
+	}
+
+	var tokenPairs []types.TokenPair
+	if err = dc.GetCodec().UnmarshalJSON(res, &tokenPairs); err != nil {
+		return ranks, utils.ErrUnmarshalJSON(err.Error())
+	}
+
+	return types.NewDepositRanks(tokenPairs), nil
+}
+
+// QueryWithdrawInfo gets the pending withdrawals of deposits of an address with their complete time
+func (dc dexClient) QueryWithdrawInfo(ownerAddr string) (withdrawInfos []types.WithdrawInfo, err error) {
+	if err = params.IsValidAccAddr(ownerAddr); err != nil {
+		return
+	}
+
+	res, err := dc.Query(fmt.Sprintf("%s/%s", types.WithdrawInfoPath, ownerAddr), nil)
+	if err != nil {
+		return withdrawInfos, utils.ErrClientQuery(err.Error())
+	}
+
+	if err = dc.GetCodec().UnmarshalJSON(res, &withdrawInfos); err != nil {
+		return withdrawInfos, utils.ErrUnmarshalJSON(err.Error())
+	}
+
+	return
+}
+
+// QueryParams gets the current params of dex module
+func (dc dexClient) QueryParams() (dexParams types.Params, err error) {
+	res, err := dc.Query(types.ParamsPath, nil)
+	if err != nil {
+		return dexParams, utils.ErrClientQuery(err.Error())
+	}
+
+	if err = dc.GetCodec().UnmarshalJSON(res, &dexParams); err != nil {
+		return dexParams, utils.ErrUnmarshalJSON(err.Error())
+	}
+
+	return
+}
@@ -2,7 +2,9 @@ package dex
 
 import (
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/okex/okchain-go-sdk/mocks"
@@ -92,3 +94,141 @@ func TestDexClient_QueryProducts(t *testing.T) {
 	require.Error(t, err)
 
 }
+
+func TestDexClient_QueryDeposits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := sdk.NewClientConfig("testURL", "testChain", sdk.BroadcastBlock, "0.01okt", 200000)
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewDexClient(mockCli.MockBaseClient))
+
+	expectedDeposits := []types.DepositInfo{
+		{Product: product, ProductDeposit: sdk.NewDecCoinFromDec("okt", sdk.MustNewDecFromStr("1024"))},
+	}
+	expectedCdc := mockCli.GetCodec()
+	queryParams, err := params.NewQueryDexInfoParams(addr, 1, 30)
+	require.NoError(t, err)
+	queryBytes := expectedCdc.MustMarshalJSON(queryParams)
+
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).Times(3)
+	mockCli.EXPECT().Query(types.DepositsPath, cmn.HexBytes(queryBytes)).Return(
+		expectedCdc.MustMarshalJSON(expectedDeposits), nil)
+
+	deposits, err := mockCli.Dex().QueryDeposits(addr, 1, 30)
+	require.NoError(t, err)
+	require.Equal(t, expectedDeposits, deposits)
+
+	_, err = mockCli.Dex().QueryDeposits("", 1, 30)
+	require.Error(t, err)
+	_, err = mockCli.Dex().QueryDeposits(addr[1:], 1, 30)
+	require.Error(t, err)
+	_, err = mockCli.Dex().QueryDeposits(addr, 0, 30)
+	require.Error(t, err)
+
+	mockCli.EXPECT().Query(types.DepositsPath, cmn.HexBytes(queryBytes)).Return(nil, errors.New("default error"))
+	_, err = mockCli.Dex().QueryDeposits(addr, 1, 30)
+	require.Error(t, err)
+}
+
+func TestDexClient_QueryDepositRanking(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := sdk.NewClientConfig("testURL", "testChain", sdk.BroadcastBlock, "0.01okt", 200000)
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewDexClient(mockCli.MockBaseClient))
+
+	tokenPairs := []types.TokenPair{
+		{BaseAssetSymbol: "btc-000", QuoteAssetSymbol: "okt", BlockHeight: 20,
+			Deposits: sdk.NewDecCoinFromDec("okt", sdk.MustNewDecFromStr("2048"))},
+		{BaseAssetSymbol: "eth-000", QuoteAssetSymbol: "okt", BlockHeight: 10,
+			Deposits: sdk.NewDecCoinFromDec("okt", sdk.MustNewDecFromStr("1024"))},
+	}
+	expectedCdc := mockCli.GetCodec()
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).Times(2)
+	mockCli.EXPECT().Query(types.MatchOrderPath, nil).Return(expectedCdc.MustMarshalJSON(tokenPairs), nil)
+
+	ranks, err := mockCli.Dex().QueryDepositRanking()
+	require.NoError(t, err)
+	require.Equal(t, []types.DepositRank{
+		{Rank: 1, Product: "btc-000_okt", Deposits: tokenPairs[0].Deposits, BlockHeight: 20},
+		{Rank: 2, Product: "eth-000_okt", Deposits: tokenPairs[1].Deposits, BlockHeight: 10},
+	}, ranks)
+
+	mockCli.EXPECT().Query(types.MatchOrderPath, nil).Return(nil, errors.New("default error"))
+	_, err = mockCli.Dex().QueryDepositRanking()
+	require.Error(t, err)
+
+	mockCli.EXPECT().Query(types.MatchOrderPath, nil).Return([]byte("{"), nil)
+	_, err = mockCli.Dex().QueryDepositRanking()
+	require.Error(t, err)
+}
+
+func TestDexClient_QueryWithdrawInfo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := sdk.NewClientConfig("testURL", "testChain", sdk.BroadcastBlock, "0.01okt", 200000)
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewDexClient(mockCli.MockBaseClient))
+
+	owner, err := sdk.AccAddressFromBech32(addr)
+	require.NoError(t, err)
+	completeTime := time.Unix(1024, 0).UTC()
+	expectedInfos := []types.WithdrawInfo{
+		{Owner: owner, Deposits: sdk.NewDecCoinFromDec("okt", sdk.MustNewDecFromStr("10.24")),
+			CompleteTime: completeTime},
+	}
+	expectedCdc := mockCli.GetCodec()
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).Times(2)
+	path := fmt.Sprintf("%s/%s", types.WithdrawInfoPath, addr)
+	mockCli.EXPECT().Query(path, nil).Return(expectedCdc.MustMarshalJSON(expectedInfos), nil)
+
+	withdrawInfos, err := mockCli.Dex().QueryWithdrawInfo(addr)
+	require.NoError(t, err)
+	require.Equal(t, expectedInfos, withdrawInfos)
+	require.False(t, withdrawInfos[0].IsComplete(completeTime.Add(-time.Second)))
+	require.True(t, withdrawInfos[0].IsComplete(completeTime))
+
+	_, err = mockCli.Dex().QueryWithdrawInfo(addr[1:])
+	require.Error(t, err)
+
+	mockCli.EXPECT().Query(path, nil).Return(nil, errors.New("default error"))
+	_, err = mockCli.Dex().QueryWithdrawInfo(addr)
+	require.Error(t, err)
+
+	mockCli.EXPECT().Query(path, nil).Return([]byte("{"), nil)
+	_, err = mockCli.Dex().QueryWithdrawInfo(addr)
+	require.Error(t, err)
+}
+
+func TestDexClient_QueryParams(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := sdk.NewClientConfig("testURL", "testChain", sdk.BroadcastBlock, "0.01okt", 200000)
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewDexClient(mockCli.MockBaseClient))
+
+	expectedParams := types.Params{
+		ListFee:              sdk.NewDecCoinFromDec("okt", sdk.MustNewDecFromStr("10000")),
+		TransferOwnershipFee: sdk.NewDecCoinFromDec("okt", sdk.MustNewDecFromStr("10")),
+		WithdrawPeriod:       72 * time.Hour,
+	}
+	expectedCdc := mockCli.GetCodec()
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).Times(2)
+	mockCli.EXPECT().Query(types.ParamsPath, nil).Return(expectedCdc.MustMarshalJSON(expectedParams), nil)
+
+	dexParams, err := mockCli.Dex().QueryParams()
+	require.NoError(t, err)
+	require.Equal(t, expectedParams, dexParams)
+
+	mockCli.EXPECT().Query(types.ParamsPath, nil).Return(nil, errors.New("default error"))
+	_, err = mockCli.Dex().QueryParams()
+	require.Error(t, err)
+
+	mockCli.EXPECT().Query(types.ParamsPath, nil).Return([]byte("{"), nil)
+	_, err = mockCli.Dex().QueryParams()
+	require.Error(t, err)
+}
@@ -1,7 +1,7 @@
 package dex
 
 import (
-	"errors"
+	"fmt"
 	"github.com/okex/okchain-go-sdk/module/dex/types"
 	sdk "github.com/okex/okchain-go-sdk/types"
 	"github.com/okex/okchain-go-sdk/types/crypto/keys"
@@ -69,15 +69,29 @@ func (dc dexClient) TransferOwnership(fromInfo keys.Info, passWd, inputPath stri
 		return
 	}
 
-	if len(stdTx.Msgs) == 0 {
-		return resp, errors.New("failed. invalid msg type")
+	return dc.BroadcastTransferOwnershipTx(fromInfo, passWd, stdTx, accNum, seqNum)
+}
+
+// BroadcastTransferOwnershipTx validates the receiver's signature of the multi-signed tx of transfer-ownership in memory,
+// then signs and broadcasts it by the owner
+func (dc dexClient) BroadcastTransferOwnershipTx(fromInfo keys.Info, passWd string, stdTx sdk.StdTx, accNum,
+	seqNum uint64) (resp sdk.TxResponse, err error) {
+	if err = params.CheckKeyParams(fromInfo, passWd); err != nil {
+		return
 	}
 
-	msg, ok := stdTx.Msgs[0].(types.MsgTransferOwnership)
-	if !ok {
-		return resp, errors.New("failed. invalid msg type")
+	msg, err := getMsgTransferOwnership(stdTx)
+	if err != nil {
+		return
 	}
 
-	return dc.BuildAndBroadcast(fromInfo.GetName(), passWd, stdTx.Memo, []sdk.Msg{msg}, accNum, seqNum)
+	if !msg.FromAddress.Equals(fromInfo.GetAddress()) {
+		return resp, fmt.Errorf("failed. only the owner %s is allowed to broadcast", msg.FromAddress)
+	}
+
+	if err = checkToSignature(msg); err != nil {
+		return
+	}
 
+	return dc.BuildAndBroadcast(fromInfo.GetName(), passWd, stdTx.Memo, []sdk.Msg{msg}, accNum, seqNum)
 }
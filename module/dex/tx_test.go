@@ -138,6 +138,9 @@ func TestDexClient_Withdraw(t *testing.T) {
 }
 
 func TestDexClient_TransferOwnership(t *testing.T) {
+	dir, _, signedPath := txPaths(t, "dex-tx")
+	defer os.RemoveAll(dir)
+
 	// set up signedTx.json
 	err := ioutil.WriteFile(signedPath, []byte(expectedSignedTxJSON), 0644)
 	require.NoError(t, err)
@@ -172,8 +175,4 @@ func TestDexClient_TransferOwnership(t *testing.T) {
 
 	_, err = mockCli.Dex().TransferOwnership(fromInfo, "", signedPath, accInfo.GetAccountNumber(), accInfo.GetSequence())
 	require.Error(t, err)
-
-	// remove the temporary file: signedTx.json
-	err = os.Remove(signedPath)
-	require.NoError(t, err)
 }
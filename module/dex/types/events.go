@@ -0,0 +1,34 @@
+package types
+
+import (
+	sdk "github.com/okex/okchain-go-sdk/types"
+)
+
+// const of the events emitted by dex module
+const (
+	EventTypeList    = "list"
+	EventTypeDeposit = "deposit"
+
+	AttributeKeyOwner      = "owner"
+	AttributeKeyBaseAsset  = "list_asset"
+	AttributeKeyQuoteAsset = "quote_asset"
+	AttributeKeyInitPrice  = "init_price"
+	AttributeKeySender     = "sender"
+	AttributeKeyProduct    = "product"
+	AttributeKeyAmount     = "amount"
+)
+
+// ListEvent - structure of the event emitted by listing a token pair
+type ListEvent struct {
+	Owner      sdk.AccAddress `json:"owner"`
+	BaseAsset  string         `json:"list_asset"`
+	QuoteAsset string         `json:"quote_asset"`
+	InitPrice  sdk.Dec        `json:"init_price"`
+}
+
+// DepositEvent - structure of the event emitted by a deposit into a product
+type DepositEvent struct {
+	Sender  sdk.AccAddress `json:"sender"`
+	Product string         `json:"product"`
+	Amount  sdk.DecCoin    `json:"amount"`
+}
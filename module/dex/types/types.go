@@ -1,6 +1,9 @@
 package types
 
 import (
+	"fmt"
+	"time"
+
 	sdk "github.com/okex/okchain-go-sdk/types"
 )
 
@@ -8,7 +11,11 @@ import (
 const (
 	ModuleName = "dex"
 
-	ProductsPath = "custom/dex/products"
+	ProductsPath     = "custom/dex/products"
+	DepositsPath     = "custom/dex/deposits"
+	MatchOrderPath   = "custom/dex/match-order"
+	WithdrawInfoPath = "custom/dex/withdraw-info"
+	ParamsPath       = "custom/dex/params"
 )
 
 var (
@@ -42,3 +49,58 @@ type TokenPair struct {
 	Deposits         sdk.DecCoin    `json:"deposits"`
 	BlockHeight      int64          `json:"block_height"`
 }
+
+// Product returns the product name of the token pair
+func (tp TokenPair) Product() string {
+	return fmt.Sprintf("%s_%s", tp.BaseAssetSymbol, tp.QuoteAssetSymbol)
+}
+
+// DepositInfo - structure of the deposits on a product owned by an address
+type DepositInfo struct {
+	Product        string      `json:"product"`
+	ProductDeposit sdk.DecCoin `json:"product_deposit"`
+}
+
+// DepositRank - structure of the place of a product in the match priority, which is decided by its deposits
+type DepositRank struct {
+	// Rank starts from 1, and the product with a smaller rank is matched earlier in a block
+	Rank        int         `json:"rank"`
+	Product     string      `json:"product"`
+	Deposits    sdk.DecCoin `json:"deposits"`
+	BlockHeight int64       `json:"block_height"`
+}
+
+// NewDepositRanks creates the deposit ranks of the token pairs in match order
+func NewDepositRanks(tokenPairs []TokenPair) []DepositRank {
+	ranks := make([]DepositRank, len(tokenPairs))
+	for i, tokenPair := range tokenPairs {
+		ranks[i] = DepositRank{
+			Rank:        i + 1,
+			Product:     tokenPair.Product(),
+			Deposits:    tokenPair.Deposits,
+			BlockHeight: tokenPair.BlockHeight,
+		}
+	}
+
+	return ranks
+}
+
+// WithdrawInfo - structure of a pending withdrawal of deposits, which is returned to the owner at the complete time
+type WithdrawInfo struct {
+	Owner        sdk.AccAddress `json:"owner"`
+	Deposits     sdk.DecCoin    `json:"deposits"`
+	CompleteTime time.Time      `json:"complete_time"`
+}
+
+// IsComplete shows whether the withdrawal is completed by the time
+func (wi WithdrawInfo) IsComplete(now time.Time) bool {
+	return !now.Before(wi.CompleteTime)
+}
+
+// Params - structure of the params of dex module on chain
+type Params struct {
+	ListFee              sdk.DecCoin `json:"list_fee"`
+	TransferOwnershipFee sdk.DecCoin `json:"transfer_ownership_fee"`
+	// WithdrawPeriod is the period from a withdrawal to the return of the deposits
+	WithdrawPeriod time.Duration `json:"withdraw_period"`
+}
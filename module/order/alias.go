@@ -4,11 +4,48 @@ import "github.com/okex/okchain-go-sdk/module/order/types"
 
 // const
 const (
-	ModuleName = types.ModuleName
+	ModuleName      = types.ModuleName
+	MaxOrdersPerMsg = types.MaxOrdersPerMsg
+
+	SideBuy  = types.SideBuy
+	SideSell = types.SideSell
+
+	OrderStatusOpen                   = types.OrderStatusOpen
+	OrderStatusFilled                 = types.OrderStatusFilled
+	OrderStatusCancelled              = types.OrderStatusCancelled
+	OrderStatusExpired                = types.OrderStatusExpired
+	OrderStatusPartialFilledCancelled = types.OrderStatusPartialFilledCancelled
+	OrderStatusPartialFilledExpired   = types.OrderStatusPartialFilledExpired
 )
 
 type (
 	// nolint
-	BookRes     = types.BookRes
-	OrderDetail = types.OrderDetail
+	BookRes            = types.BookRes
+	OrderDetail        = types.OrderDetail
+	OrdersEvent        = types.OrdersEvent
+	FillEvent          = types.FillEvent
+	Side               = types.Side
+	OrderItem          = types.OrderItem
+	OrderResult        = types.OrderResult
+	OrderStatus        = types.OrderStatus
+	ProductRule        = types.ProductRule
+	CancelOrdersResult = types.CancelOrdersResult
+	ReplaceResult      = types.ReplaceResult
+	Params             = types.Params
+	OrderEstimate      = types.OrderEstimate
+)
+
+var (
+	// NewOrderItemDec is the alias of the one under order/types
+	NewOrderItemDec = types.NewOrderItemDec
+	// ParseOrderItem is the alias of the one under order/types
+	ParseOrderItem = types.ParseOrderItem
+	// ParseSide is the alias of the one under order/types
+	ParseSide = types.ParseSide
+	// NewProductRule is the alias of the one under order/types
+	NewProductRule = types.NewProductRule
+	// GetReplacedOrderIDs is the alias of the one under order/types
+	GetReplacedOrderIDs = types.GetReplacedOrderIDs
+	// NewOrderEstimate is the alias of the one under order/types
+	NewOrderEstimate = types.NewOrderEstimate
 )
@@ -0,0 +1,75 @@
+package order
+
+import (
+	"sync"
+	"time"
+
+	"github.com/okex/okchain-go-sdk/module/order/types"
+)
+
+const (
+	// productRulesTTL is the time that the cached product rules expire in, so that the changes of the token pairs on
+	// chain like delisting are reloaded at last
+	productRulesTTL = 10 * time.Minute
+	// unlistedProductTTL is the time that a product missing in the rules loaded is taken as unlisted without reloading
+	unlistedProductTTL = time.Minute
+)
+
+// productRulesCache caches the precision rules of the products by product name, which are fed by the token pairs on
+// chain. The products missing in the rules are cached as unlisted as well, so that they don't reload all the rules
+// on every lookup
+type productRulesCache struct {
+	mtx      sync.RWMutex
+	ttl      time.Duration
+	now      func() time.Time
+	rules    map[string]types.ProductRule
+	expireAt time.Time
+	// unlisted is the expiry of the products missing in the rules by product name
+	unlisted map[string]time.Time
+}
+
+func newProductRulesCache() *productRulesCache {
+	return &productRulesCache{
+		ttl:      productRulesTTL,
+		now:      time.Now,
+		rules:    make(map[string]types.ProductRule),
+		unlisted: make(map[string]time.Time),
+	}
+}
+
+// get returns the rule of a product if the rules haven't expired. The unlisted flag shows whether the product is known
+// to be missing in the rules, which needs no reloading
+func (prc *productRulesCache) get(product string) (rule types.ProductRule, ok, unlisted bool) {
+	prc.mtx.RLock()
+	defer prc.mtx.RUnlock()
+	now := prc.now()
+	if expireAt, found := prc.unlisted[product]; found && !now.After(expireAt) {
+		return rule, false, true
+	}
+
+	if now.After(prc.expireAt) {
+		return rule, false, false
+	}
+
+	rule, ok = prc.rules[product]
+	return
+}
+
+// reset replaces all the cached rules, so that the delisted products are dropped
+func (prc *productRulesCache) reset(rules []types.ProductRule) {
+	prc.mtx.Lock()
+	defer prc.mtx.Unlock()
+	prc.rules = make(map[string]types.ProductRule, len(rules))
+	for _, rule := range rules {
+		prc.rules[rule.Product] = rule
+	}
+	prc.expireAt = prc.now().Add(prc.ttl)
+	prc.unlisted = make(map[string]time.Time)
+}
+
+// setUnlisted caches a product missing in the rules loaded
+func (prc *productRulesCache) setUnlisted(product string) {
+	prc.mtx.Lock()
+	defer prc.mtx.Unlock()
+	prc.unlisted[product] = prc.now().Add(unlistedProductTTL)
+}
@@ -10,6 +10,7 @@ var _ sdk.Module = (*orderClient)(nil)
 
 type orderClient struct {
 	sdk.BaseClient
+	rulesCache *productRulesCache
 }
 
 // RegisterCodec registers the msg type in order module
@@ -24,5 +25,5 @@ func (orderClient) Name() string {
 
 // NewOrderClient creates a new instance of order client as implement
 func NewOrderClient(baseClient sdk.BaseClient) exposed.Order {
-	return orderClient{baseClient}
+	return orderClient{baseClient, newProductRulesCache()}
 }
@@ -1,12 +1,21 @@
 package order
 
 import (
+	"errors"
 	"fmt"
+	dextypes "github.com/okex/okchain-go-sdk/module/dex/types"
 	"github.com/okex/okchain-go-sdk/module/order/types"
 	"github.com/okex/okchain-go-sdk/types/params"
 	"github.com/okex/okchain-go-sdk/utils"
 )
 
+const (
+	// productsPerPage is the page size of querying all token pairs for the product rules
+	productsPerPage = 200
+	// maxProductPages caps the pages of querying all token pairs, in case the paging is ignored by the node
+	maxProductPages = 100
+)
+
 // QueryDepthBook gets the current depth book info of a specific product
 func (oc orderClient) QueryDepthBook(product string) (depthBook types.BookRes, err error) {
 	depthBookParams := params.NewQueryDepthBookParams(product, 200)
@@ -44,3 +53,151 @@ func (oc orderClient) QueryOrderDetail(orderID string) (orderDetail types.OrderD
 
 	return
 }
+
+// QueryParams gets the current params of order module
+func (oc orderClient) QueryParams() (orderParams types.Params, err error) {
+	res, err := oc.Query(types.ParamsPath, nil)
+	if err != nil {
+		return orderParams, utils.ErrClientQuery(err.Error())
+	}
+
+	if err = oc.GetCodec().UnmarshalJSON(res, &orderParams); err != nil {
+		return orderParams, utils.ErrUnmarshalJSON(err.Error())
+	}
+
+	return
+}
+
+// QueryProductRule gets the precision rule of a product for placing orders. The rules of all products are cached once
+// queried, and they are reloaded when they expire or the product is missing in the cache
+func (oc orderClient) QueryProductRule(product string) (rule types.ProductRule, err error) {
+	if len(product) == 0 {
+		return rule, errors.New("failed. empty product")
+	}
+
+	rule, ok, unlisted := oc.rulesCache.get(product)
+	if ok {
+		return
+	}
+
+	if !unlisted {
+		if err = oc.RefreshProductRules(); err != nil {
+			return
+		}
+
+		if rule, ok, _ = oc.rulesCache.get(product); ok {
+			return
+		}
+		oc.rulesCache.setUnlisted(product)
+	}
+
+	return rule, fmt.Errorf("failed. product %s is not listed", product)
+}
+
+// RefreshProductRules reloads the precision rules of all products from the token pairs on chain. The paging stops once
+// a page repeats the previous one, which means the node returns the full list regardless of the page
+func (oc orderClient) RefreshProductRules() error {
+	var rules []types.ProductRule
+	var lastPage []dextypes.TokenPair
+	for page := 1; ; page++ {
+		if page > maxProductPages {
+			return fmt.Errorf("failed. token pairs exceed %d pages", maxProductPages)
+		}
+
+		tokenPairs, err := oc.queryTokenPairs(page, productsPerPage)
+		if err != nil {
+			return err
+		}
+
+		if isSameProducts(tokenPairs, lastPage) {
+			break
+		}
+
+		for _, tokenPair := range tokenPairs {
+			rules = append(rules, types.NewProductRule(tokenPair))
+		}
+
+		if len(tokenPairs) < productsPerPage {
+			break
+		}
+		lastPage = tokenPairs
+	}
+
+	oc.rulesCache.reset(rules)
+	return nil
+}
+
+// isSameProducts shows whether the two pages of token pairs are with the same products in the same order
+func isSameProducts(tokenPairs, lastPage []dextypes.TokenPair) bool {
+	if len(tokenPairs) == 0 || len(tokenPairs) != len(lastPage) {
+		return false
+	}
+
+	for i := range tokenPairs {
+		if tokenPairs[i].Product() != lastPage[i].Product() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// RoundOrderItems truncates the prices and quantities of the order items to the digits allowed by the product rules
+func (oc orderClient) RoundOrderItems(orderItems []types.OrderItem) ([]types.OrderItem, error) {
+	roundedItems := make([]types.OrderItem, len(orderItems))
+	for i, orderItem := range orderItems {
+		if err := orderItem.Validate(); err != nil {
+			return nil, err
+		}
+
+		rule, err := oc.QueryProductRule(orderItem.Product)
+		if err != nil {
+			return nil, err
+		}
+
+		if roundedItems[i], err = rule.Round(orderItem); err != nil {
+			return nil, err
+		}
+	}
+
+	return roundedItems, nil
+}
+
+// checkProductRules checks the order items against the product rules before placing orders
+func (oc orderClient) checkProductRules(orderItems []types.OrderItem) error {
+	for _, orderItem := range orderItems {
+		rule, err := oc.QueryProductRule(orderItem.Product)
+		if err != nil {
+			return fmt.Errorf("failed. check product rules error: %s", err)
+		}
+
+		if err = rule.Check(orderItem); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (oc orderClient) queryTokenPairs(page, perPage int) (tokenPairs []dextypes.TokenPair, err error) {
+	queryParams, err := params.NewQueryDexInfoParams("", page, perPage)
+	if err != nil {
+		return
+	}
+
+	jsonBytes, err := oc.GetCodec().MarshalJSON(queryParams)
+	if err != nil {
+		return tokenPairs, utils.ErrMarshalJSON(err.Error())
+	}
+
+	res, err := oc.Query(dextypes.ProductsPath, jsonBytes)
+	if err != nil {
+		return tokenPairs, utils.ErrClientQuery(err.Error())
+	}
+
+	if err = oc.GetCodec().UnmarshalJSON(res, &tokenPairs); err != nil {
+		return tokenPairs, utils.ErrUnmarshalJSON(err.Error())
+	}
+
+	return
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/okex/okchain-go-sdk/mocks"
+	dextypes "github.com/okex/okchain-go-sdk/module/dex/types"
 	"github.com/okex/okchain-go-sdk/module/order/types"
 	sdk "github.com/okex/okchain-go-sdk/types"
 	"github.com/okex/okchain-go-sdk/types/params"
@@ -13,6 +14,7 @@ import (
 	cmn "github.com/tendermint/tendermint/libs/common"
 
 	"testing"
+	"time"
 )
 
 const (
@@ -124,3 +126,161 @@ func TestOrderClient_QueryDepthBook(t *testing.T) {
 	_, err = mockCli.Order().QueryDepthBook(product)
 	require.Error(t, err)
 }
+
+func TestOrderClient_RoundOrderItems(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := sdk.NewClientConfig("testURL", "testChain", sdk.BroadcastBlock, "0.01okt", 200000)
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewOrderClient(mockCli.MockBaseClient))
+
+	expectProductRules(mockCli)
+	rule, err := mockCli.Order().QueryProductRule(product)
+	require.NoError(t, err)
+	require.Equal(t, product, rule.Product)
+	require.Equal(t, int64(4), rule.MaxPriceDigit)
+	require.False(t, rule.Delisting)
+
+	orderItems, err := mockCli.Order().RoundOrderItems([]types.OrderItem{
+		types.NewOrderItemDec(product, types.SideBuy, sdk.MustNewDecFromStr("1.23456789"),
+			sdk.MustNewDecFromStr("9.87659999")),
+	})
+	require.NoError(t, err)
+	require.Equal(t, sdk.MustNewDecFromStr("1.2345"), orderItems[0].Price)
+	require.Equal(t, sdk.MustNewDecFromStr("9.8765"), orderItems[0].Quantity)
+
+	badItems := []types.OrderItem{
+		types.NewOrderItemDec(product, types.SideBuy, sdk.MustNewDecFromStr("0.00001"), sdk.OneDec()),
+		types.NewOrderItemDec(product, types.SideBuy, sdk.OneDec(), sdk.MustNewDecFromStr("0.00099")),
+		types.NewOrderItemDec("eth-000_okt", types.SideBuy, sdk.OneDec(), sdk.OneDec()),
+		types.NewOrderItemDec(product, types.SideBuy, sdk.ZeroDec(), sdk.OneDec()),
+	}
+	for _, badItem := range badItems {
+		_, err = mockCli.Order().RoundOrderItems([]types.OrderItem{badItem})
+		require.Error(t, err)
+	}
+
+	_, err = mockCli.Order().QueryProductRule("")
+	require.Error(t, err)
+
+	mockCli.EXPECT().GetCodec().Return(mockCli.GetCodec())
+	mockCli.EXPECT().Query(gomock.Any(), gomock.Any()).Return(nil, errors.New("default error"))
+	_, err = mockCli.Order().QueryProductRule("xxb-000_okt")
+	require.Error(t, err)
+}
+
+func TestOrderClient_RefreshProductRules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := sdk.NewClientConfig("testURL", "testChain", sdk.BroadcastBlock, "0.01okt", 200000)
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewOrderClient(mockCli.MockBaseClient))
+
+	// the node ignores the paging and returns the full list of 200 token pairs for every page
+	tokenPairs := make([]dextypes.TokenPair, productsPerPage)
+	for i := range tokenPairs {
+		tokenPairs[i] = dextypes.TokenPair{BaseAssetSymbol: fmt.Sprintf("t%d-000", i), QuoteAssetSymbol: "okt",
+			MaxPriceDigit: 4, MaxQuantityDigit: 4, MinQuantity: sdk.MustNewDecFromStr("0.001")}
+	}
+	expectedCdc := mockCli.GetCodec()
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).Times(4)
+	mockCli.EXPECT().Query(dextypes.ProductsPath, gomock.Any()).Return(expectedCdc.MustMarshalJSON(tokenPairs), nil).
+		Times(2)
+	require.NoError(t, mockCli.Order().RefreshProductRules())
+
+	rule, err := mockCli.Order().QueryProductRule("t199-000_okt")
+	require.NoError(t, err)
+	require.Equal(t, "t199-000_okt", rule.Product)
+
+	// the missing product reloads the rules once and is cached as unlisted
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).Times(4)
+	mockCli.EXPECT().Query(dextypes.ProductsPath, gomock.Any()).Return(expectedCdc.MustMarshalJSON(tokenPairs), nil).
+		Times(2)
+	_, err = mockCli.Order().QueryProductRule("t200-000_okt")
+	require.Error(t, err)
+	_, err = mockCli.Order().QueryProductRule("t200-000_okt")
+	require.Error(t, err)
+
+	// the expired rules are reloaded
+	now := time.Now()
+	rulesCache := mockCli.Order().(orderClient).rulesCache
+	rulesCache.now = func() time.Time { return now }
+	_, err = mockCli.Order().QueryProductRule("t200-000_okt")
+	require.Error(t, err)
+	now = now.Add(productRulesTTL + time.Second)
+	tokenPairs[0].Delisting = true
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).Times(4)
+	mockCli.EXPECT().Query(dextypes.ProductsPath, gomock.Any()).Return(expectedCdc.MustMarshalJSON(tokenPairs), nil).
+		Times(2)
+	rule, err = mockCli.Order().QueryProductRule("t0-000_okt")
+	require.NoError(t, err)
+	require.True(t, rule.Delisting)
+}
+
+func TestOrderClient_QueryParams(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := sdk.NewClientConfig("testURL", "testChain", sdk.BroadcastBlock, "0.01okt", 200000)
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewOrderClient(mockCli.MockBaseClient))
+
+	expectedParams := types.Params{
+		OrderExpireBlocks: 259200,
+		MaxDealsPerBlock:  1000,
+		FeePerBlock:       sdk.NewDecCoinFromDec("okt", sdk.MustNewDecFromStr("0.000001")),
+		TradeFeeRate:      sdk.MustNewDecFromStr("0.001"),
+	}
+	expectedCdc := mockCli.GetCodec()
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).Times(2)
+	mockCli.EXPECT().Query(types.ParamsPath, nil).Return(expectedCdc.MustMarshalJSON(expectedParams), nil)
+
+	orderParams, err := mockCli.Order().QueryParams()
+	require.NoError(t, err)
+	require.Equal(t, expectedParams, orderParams)
+
+	mockCli.EXPECT().Query(types.ParamsPath, nil).Return(nil, errors.New("default error"))
+	_, err = mockCli.Order().QueryParams()
+	require.Error(t, err)
+
+	mockCli.EXPECT().Query(types.ParamsPath, nil).Return([]byte("{"), nil)
+	_, err = mockCli.Order().QueryParams()
+	require.Error(t, err)
+}
+
+func TestNewOrderEstimate(t *testing.T) {
+	orderParams := types.Params{
+		OrderExpireBlocks: 100,
+		FeePerBlock:       sdk.NewDecCoinFromDec("okt", sdk.MustNewDecFromStr("0.000001")),
+		TradeFeeRate:      sdk.MustNewDecFromStr("0.001"),
+	}
+	blockTime := time.Unix(1024, 0).UTC()
+
+	buyItem := types.NewOrderItemDec(product, types.SideBuy, sdk.MustNewDecFromStr("2.5"), sdk.MustNewDecFromStr("4"))
+	estimate, err := types.NewOrderEstimate(buyItem, orderParams, 10, blockTime, 3*time.Second)
+	require.NoError(t, err)
+	require.Equal(t, int64(110), estimate.ExpireHeight)
+	require.Equal(t, blockTime.Add(300*time.Second), estimate.ExpireTime)
+	require.Equal(t, sdk.NewDecCoinFromDec("okt", sdk.MustNewDecFromStr("0.0001")), estimate.MaxRestFee)
+	require.Equal(t, sdk.NewDecCoinFromDec("btc-000", sdk.MustNewDecFromStr("0.004")), estimate.MaxTradeFee)
+	require.Equal(t, sdk.NewDecCoinFromDec("okt", sdk.MustNewDecFromStr("10")), estimate.Locked)
+
+	sellItem := types.NewOrderItemDec(product, types.SideSell, sdk.MustNewDecFromStr("2.5"), sdk.MustNewDecFromStr("4"))
+	estimate, err = types.NewOrderEstimate(sellItem, orderParams, 10, blockTime, 3*time.Second)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewDecCoinFromDec("okt", sdk.MustNewDecFromStr("0.01")), estimate.MaxTradeFee)
+	require.Equal(t, sdk.NewDecCoinFromDec("btc-000", sdk.MustNewDecFromStr("4")), estimate.Locked)
+
+	_, err = types.NewOrderEstimate(sellItem, orderParams, 10, blockTime, 0)
+	require.Error(t, err)
+	_, err = types.NewOrderEstimate(sellItem, types.Params{}, 10, blockTime, time.Second)
+	require.Error(t, err)
+	_, err = types.NewOrderEstimate(types.NewOrderItemDec("btc-000", types.SideSell, sdk.OneDec(), sdk.OneDec()),
+		orderParams, 10, blockTime, time.Second)
+	require.Error(t, err)
+	_, err = types.NewOrderEstimate(types.NewOrderItemDec(product, "sell", sdk.OneDec(), sdk.OneDec()),
+		orderParams, 10, blockTime, time.Second)
+	require.Error(t, err)
+}
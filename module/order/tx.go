@@ -2,14 +2,17 @@ package order
 
 import (
 	"errors"
+	"fmt"
 	"github.com/okex/okchain-go-sdk/module/order/types"
 	sdk "github.com/okex/okchain-go-sdk/types"
 	"github.com/okex/okchain-go-sdk/types/crypto/keys"
 	"github.com/okex/okchain-go-sdk/types/params"
+	"github.com/okex/okchain-go-sdk/utils"
 	"strings"
 )
 
 // NewOrders places orders with some detail info
+// NOTE: the orders are checked against the cached product rules first, which fails if the rules can't be loaded
 func (oc orderClient) NewOrders(fromInfo keys.Info, passWd, products, sides, prices, quantities, memo string, accNum,
 	seqNum uint64) (resp sdk.TxResponse, err error) {
 	if len(products) == 0 || len(sides) == 0 || len(prices) == 0 || len(quantities) == 0 {
@@ -24,7 +27,18 @@ func (oc orderClient) NewOrders(fromInfo keys.Info, passWd, products, sides, pri
 		return
 	}
 
-	orderItems := types.BuildOrderItems(productStrs, sideStrs, priceStrs, quantityStrs)
+	orderItems := make([]types.OrderItem, len(productStrs))
+	for i := range orderItems {
+		orderItems[i], err = types.ParseOrderItem(productStrs[i], sideStrs[i], priceStrs[i], quantityStrs[i])
+		if err != nil {
+			return
+		}
+	}
+
+	if err = oc.checkProductRules(orderItems); err != nil {
+		return
+	}
+
 	msg := types.NewMsgNewOrders(fromInfo.GetAddress(), orderItems)
 
 	return oc.BuildAndBroadcast(fromInfo.GetName(), passWd, memo, []sdk.Msg{msg}, accNum, seqNum)
@@ -49,3 +63,191 @@ func (oc orderClient) CancelOrders(fromInfo keys.Info, passWd, orderIDs, memo st
 	return oc.BuildAndBroadcast(fromInfo.GetName(), passWd, memo, []sdk.Msg{msg}, accNum, seqNum)
 
 }
+
+// NewOrdersWithItems places orders with the typed order items and returns the result of each order
+// NOTE: no error returns once the tx is broadcasted. The results are empty if they can't be decoded from the events
+func (oc orderClient) NewOrdersWithItems(fromInfo keys.Info, passWd string, orderItems []types.OrderItem, memo string,
+	accNum, seqNum uint64) (resp sdk.TxResponse, results []types.OrderResult, err error) {
+	if err = params.CheckOrderItemsParams(fromInfo, passWd, orderItems); err != nil {
+		return
+	}
+
+	if err = oc.checkProductRules(orderItems); err != nil {
+		return
+	}
+
+	msg := types.NewMsgNewOrders(fromInfo.GetAddress(), orderItems)
+	if resp, err = oc.BuildAndBroadcast(fromInfo.GetName(), passWd, memo, []sdk.Msg{msg}, accNum, seqNum); err != nil {
+		return
+	}
+
+	return resp, getOrderResults(resp, utils.ParseNewOrderEvents), nil
+}
+
+// CancelOrdersWithIDs cancels orders by the orderID slice and returns the result of each order
+// NOTE: no error returns once the tx is broadcasted. The results are empty if they can't be decoded from the events
+func (oc orderClient) CancelOrdersWithIDs(fromInfo keys.Info, passWd string, orderIDs []string, memo string, accNum,
+	seqNum uint64) (resp sdk.TxResponse, results []types.OrderResult, err error) {
+	if len(orderIDs) == 0 {
+		return resp, results, errors.New("failed. empty orderIDs input")
+	}
+
+	if err = params.CheckCancelOrderParams(fromInfo, passWd, orderIDs); err != nil {
+		return
+	}
+
+	msg := types.NewMsgCancelOrders(fromInfo.GetAddress(), orderIDs)
+	if resp, err = oc.BuildAndBroadcast(fromInfo.GetName(), passWd, memo, []sdk.Msg{msg}, accNum, seqNum); err != nil {
+		return
+	}
+
+	return resp, getOrderResults(resp, utils.ParseCancelOrderEvents), nil
+}
+
+// ReplaceOrders cancels the orders in a tx with seqNum, and then places the new ones paired by index with the cancelled
+// orders in the next tx with seqNum+1. It returns the responses of both txs and the results of each replacement
+// NOTE: the replacement isn't atomic. A new order is placed only after its old one is cancelled, so the exposure is
+// never doubled. No new order is placed if the cancel results are unavailable, such as the tx broadcasted in async or
+// sync mode. The results keep the partial state if the second tx fails, and only the ones with IsReplaced true are
+// replaced
+func (oc orderClient) ReplaceOrders(fromInfo keys.Info, passWd string, orderIDs []string, orderItems []types.OrderItem,
+	memo string, accNum, seqNum uint64) (cancelResp, newResp sdk.TxResponse, results []types.ReplaceResult, err error) {
+	if err = params.CheckReplaceOrdersParams(fromInfo, passWd, orderIDs, orderItems); err != nil {
+		return
+	}
+
+	if err = oc.checkProductRules(orderItems); err != nil {
+		return
+	}
+
+	cancelResp, cancelResults, err := oc.CancelOrdersWithIDs(fromInfo, passWd, orderIDs, memo, accNum, seqNum)
+	if err != nil {
+		return
+	}
+
+	if len(cancelResults) != len(orderIDs) {
+		return cancelResp, newResp, nil, fmt.Errorf("failed. no cancel result of tx %s, so no new order is placed",
+			cancelResp.TxHash)
+	}
+
+	results = make([]types.ReplaceResult, len(orderIDs))
+	var newItems []types.OrderItem
+	var newIndexes []int
+	for i, orderID := range orderIDs {
+		results[i] = types.ReplaceResult{OldOrderID: orderID, CancelResult: cancelResults[i]}
+		if cancelResults[i].Code == 0 && cancelResults[i].OrderID == orderID {
+			newItems = append(newItems, orderItems[i])
+			newIndexes = append(newIndexes, i)
+		}
+	}
+
+	if len(newItems) == 0 {
+		return
+	}
+
+	newResp, newResults, err := oc.NewOrdersWithItems(fromInfo, passWd, newItems, memo, accNum, seqNum+1)
+	if err != nil {
+		return cancelResp, newResp, results, fmt.Errorf("failed. place the new orders after cancelling error: %s", err)
+	}
+
+	// the new orders are unconfirmed without results
+	if len(newResults) != len(newItems) {
+		return
+	}
+
+	for i, index := range newIndexes {
+		results[index].NewOrderID = newResults[i].OrderID
+		results[index].NewResult = newResults[i]
+	}
+
+	return
+}
+
+// CancelOrdersInBatches cancels the orders in as few txs as MaxOrdersPerMsg allows, with consecutive sequence numbers
+// starting from seqNum. It stops at the first tx failed or rejected, and reports the IDs cancelled, failed, unconfirmed
+// and unsent. The orders of a tx broadcasted with an error are unconfirmed rather than failed
+func (oc orderClient) CancelOrdersInBatches(fromInfo keys.Info, passWd string, orderIDs []string, memo string, accNum,
+	seqNum uint64) (result types.CancelOrdersResult, err error) {
+	if len(orderIDs) == 0 {
+		return result, errors.New("failed. empty orderIDs input")
+	}
+
+	if err = params.CheckCancelOrderParams(fromInfo, passWd, orderIDs); err != nil {
+		return
+	}
+
+	batches := (len(orderIDs) + types.MaxOrdersPerMsg - 1) / types.MaxOrdersPerMsg
+	for i := 0; i < batches; i++ {
+		start, end := i*types.MaxOrdersPerMsg, (i+1)*types.MaxOrdersPerMsg
+		if end > len(orderIDs) {
+			end = len(orderIDs)
+		}
+
+		batchIDs := orderIDs[start:end]
+		resp, results, err := oc.CancelOrdersWithIDs(fromInfo, passWd, batchIDs, memo, accNum, seqNum)
+		if len(resp.TxHash) != 0 {
+			result.TxHashes = append(result.TxHashes, resp.TxHash)
+		}
+
+		switch {
+		case resp.Code != 0:
+			err = fmt.Errorf("failed. tx %s is rejected with code %d: %s", resp.TxHash, resp.Code, resp.RawLog)
+		case err != nil && len(resp.TxHash) != 0:
+			// the tx may be committed in spite of the error, so it takes the sequence with its orders unconfirmed
+			result.Unconfirmed = append(result.Unconfirmed, batchIDs...)
+			seqNum++
+			continue
+		}
+
+		if err != nil {
+			for _, orderID := range batchIDs {
+				result.Failed = append(result.Failed, types.OrderResult{Code: resp.Code, Message: err.Error(),
+					OrderID: orderID})
+			}
+			result.Unsent = append(result.Unsent, orderIDs[end:]...)
+			return result, fmt.Errorf("failed. batch %d of %d in cancelling orders error: %s", i+1, batches, err)
+		}
+
+		collectCancelResults(&result, batchIDs, results)
+		seqNum++
+	}
+
+	return result, nil
+}
+
+// collectCancelResults sorts the orders of a batch by their results. The orders without results are unconfirmed
+func collectCancelResults(result *types.CancelOrdersResult, orderIDs []string, results []types.OrderResult) {
+	resultMap := make(map[string]types.OrderResult, len(results))
+	for _, orderResult := range results {
+		resultMap[orderResult.OrderID] = orderResult
+	}
+
+	for _, orderID := range orderIDs {
+		orderResult, ok := resultMap[orderID]
+		switch {
+		case !ok:
+			result.Unconfirmed = append(result.Unconfirmed, orderID)
+		case orderResult.Code == 0:
+			result.Cancelled = append(result.Cancelled, orderID)
+		default:
+			result.Failed = append(result.Failed, orderResult)
+		}
+	}
+}
+
+// getOrderResults decodes the result of each order from the events in the tx response. No result returns if the events
+// are unavailable, such as the tx broadcasted in async or sync mode, or malformed. A decoding failure isn't an error,
+// since the tx has been broadcasted anyway and mustn't be sent again
+func getOrderResults(resp sdk.TxResponse, parse func(sdk.StringEvents) ([]types.OrdersEvent, error)) (
+	results []types.OrderResult) {
+	ordersEvents, err := parse(utils.GetEventsFromResponse(&resp))
+	if err != nil {
+		return nil
+	}
+
+	for _, ordersEvent := range ordersEvents {
+		results = append(results, ordersEvent.Results...)
+	}
+
+	return
+}
@@ -1,16 +1,32 @@
 package order
 
 import (
+	"errors"
 	"fmt"
 	"github.com/golang/mock/gomock"
 	"github.com/okex/okchain-go-sdk/mocks"
 	"github.com/okex/okchain-go-sdk/module/auth"
+	dextypes "github.com/okex/okchain-go-sdk/module/dex/types"
+	"github.com/okex/okchain-go-sdk/module/order/types"
 	sdk "github.com/okex/okchain-go-sdk/types"
 	"github.com/okex/okchain-go-sdk/utils"
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 )
 
+func expectProductRules(mockCli mocks.MockClient) {
+	tokenPairs := []dextypes.TokenPair{
+		{BaseAssetSymbol: "btc-000", QuoteAssetSymbol: "okt", MaxPriceDigit: 4, MaxQuantityDigit: 4,
+			MinQuantity: sdk.MustNewDecFromStr("0.001")},
+		{BaseAssetSymbol: "eth-000", QuoteAssetSymbol: "okt", MaxPriceDigit: 4, MaxQuantityDigit: 4,
+			MinQuantity: sdk.MustNewDecFromStr("0.001"), Delisting: true},
+	}
+	expectedCdc := mockCli.GetCodec()
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).Times(2)
+	mockCli.EXPECT().Query(dextypes.ProductsPath, gomock.Any()).Return(expectedCdc.MustMarshalJSON(tokenPairs), nil)
+}
+
 func TestOrderClient_NewOrders(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -33,6 +49,7 @@ func TestOrderClient_NewOrders(t *testing.T) {
 	mockCli.EXPECT().BuildAndBroadcast(
 		fromInfo.GetName(), passWd, memo, gomock.AssignableToTypeOf([]sdk.Msg{}), accInfo.GetAccountNumber(),
 		accInfo.GetSequence()).Return(mocks.DefaultMockSuccessTxResponse(), nil)
+	expectProductRules(mockCli)
 
 	products := fmt.Sprintf("%s,%s,%s", product, product, product)
 	res, err := mockCli.Order().NewOrders(fromInfo, passWd, products, "BUY,BUY,SELL", "1.024,2.048,4.096",
@@ -56,9 +73,19 @@ func TestOrderClient_NewOrders(t *testing.T) {
 		memo, accInfo.GetAccountNumber(), accInfo.GetSequence())
 	require.Error(t, err)
 
+	// invalid price without panic
+	_, err = mockCli.Order().NewOrders(fromInfo, passWd, products, "BUY,BUY,SELL", "1.024,2.048,4.0.96",
+		"10.24,20.48,30.72", memo, accInfo.GetAccountNumber(), accInfo.GetSequence())
+	require.Error(t, err)
+
 	_, err = mockCli.Order().NewOrders(fromInfo, "", products, "BUY,BUY,SELL", "1.024,2.048,4.096",
 		"10.24,20.48,30.72", memo, accInfo.GetAccountNumber(), accInfo.GetSequence())
 	require.Error(t, err)
+
+	// price beyond the max price digit of the product
+	_, err = mockCli.Order().NewOrders(fromInfo, passWd, products, "BUY,BUY,SELL", "1.024,2.048,4.09601",
+		"10.24,20.48,30.72", memo, accInfo.GetAccountNumber(), accInfo.GetSequence())
+	require.Error(t, err)
 }
 
 func TestOrderClient_CancelOrders(t *testing.T) {
@@ -101,3 +128,315 @@ func TestOrderClient_CancelOrders(t *testing.T) {
 		accInfo.GetAccountNumber(), accInfo.GetSequence())
 	require.Error(t, err)
 }
+
+func TestOrderClient_NewOrdersWithItems(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := sdk.NewClientConfig("testURL", "testChain", sdk.BroadcastBlock, "0.01okt", 200000)
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewOrderClient(mockCli.MockBaseClient))
+
+	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
+	require.NoError(t, err)
+
+	orderItems := []types.OrderItem{
+		types.NewOrderItemDec(product, types.SideBuy, sdk.MustNewDecFromStr("1.024"), sdk.MustNewDecFromStr("10.24")),
+		types.NewOrderItemDec(product, types.SideSell, sdk.MustNewDecFromStr("2.048"), sdk.MustNewDecFromStr("20.48")),
+	}
+	expectedResults := []types.OrderResult{
+		{Code: 0, Message: "", OrderID: "ID0000000001-1"},
+		{Code: 1, Message: "insufficient coins", OrderID: ""},
+	}
+
+	mockCli.EXPECT().BuildAndBroadcast(fromInfo.GetName(), passWd, memo,
+		[]sdk.Msg{types.NewMsgNewOrders(fromInfo.GetAddress(), orderItems)}, uint64(1), uint64(2)).
+		Return(mocks.MockOrdersTxResponse(types.ActionNewOrders, addr, expectedResults), nil)
+	expectProductRules(mockCli)
+	res, results, err := mockCli.Order().NewOrdersWithItems(fromInfo, passWd, orderItems, memo, 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), res.Code)
+	require.Equal(t, expectedResults, results)
+
+	// no events in the response
+	mockCli.EXPECT().BuildAndBroadcast(fromInfo.GetName(), passWd, memo, gomock.Any(), uint64(1), uint64(2)).
+		Return(mocks.DefaultMockSuccessTxResponse(), nil)
+	_, results, err = mockCli.Order().NewOrdersWithItems(fromInfo, passWd, orderItems, memo, 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(results))
+
+	// malformed events in the response of the broadcasted tx
+	mockCli.EXPECT().BuildAndBroadcast(fromInfo.GetName(), passWd, memo, gomock.Any(), uint64(1), uint64(2)).
+		Return(mocks.MockOrdersTxResponse(types.ActionNewOrders, "bad sender", expectedResults), nil)
+	res, results, err = mockCli.Order().NewOrdersWithItems(fromInfo, passWd, orderItems, memo, 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, "default tx hash", res.TxHash)
+	require.Equal(t, 0, len(results))
+
+	badItems := []types.OrderItem{
+		types.NewOrderItemDec("", types.SideBuy, sdk.OneDec(), sdk.OneDec()),
+		types.NewOrderItemDec(product, "buy", sdk.OneDec(), sdk.OneDec()),
+		types.NewOrderItemDec(product, types.SideBuy, sdk.ZeroDec(), sdk.OneDec()),
+		types.NewOrderItemDec(product, types.SideSell, sdk.OneDec(), sdk.OneDec().Neg()),
+		types.NewOrderItemDec(product, types.SideSell, sdk.OneDec(), sdk.Dec{}),
+	}
+	for _, badItem := range badItems {
+		_, _, err = mockCli.Order().NewOrdersWithItems(fromInfo, passWd, []types.OrderItem{badItem}, memo, 1, 2)
+		require.Error(t, err)
+	}
+
+	_, _, err = mockCli.Order().NewOrdersWithItems(fromInfo, passWd, nil, memo, 1, 2)
+	require.Error(t, err)
+
+	_, _, err = mockCli.Order().NewOrdersWithItems(fromInfo, "", orderItems, memo, 1, 2)
+	require.Error(t, err)
+
+	// against the cached product rules
+	ruleBreakingItems := []types.OrderItem{
+		types.NewOrderItemDec(product, types.SideBuy, sdk.MustNewDecFromStr("1.00001"), sdk.OneDec()),
+		types.NewOrderItemDec(product, types.SideBuy, sdk.OneDec(), sdk.MustNewDecFromStr("1.00001")),
+		types.NewOrderItemDec(product, types.SideBuy, sdk.OneDec(), sdk.MustNewDecFromStr("0.0001")),
+		types.NewOrderItemDec("eth-000_okt", types.SideBuy, sdk.OneDec(), sdk.OneDec()),
+	}
+	for _, ruleBreakingItem := range ruleBreakingItems {
+		_, _, err = mockCli.Order().NewOrdersWithItems(fromInfo, passWd, []types.OrderItem{ruleBreakingItem}, memo, 1,
+			2)
+		require.Error(t, err)
+	}
+
+	// unlisted product after refreshing the rules
+	expectProductRules(mockCli)
+	unlistedItems := []types.OrderItem{types.NewOrderItemDec("xxb-000_okt", types.SideBuy, sdk.OneDec(), sdk.OneDec())}
+	_, _, err = mockCli.Order().NewOrdersWithItems(fromInfo, passWd, unlistedItems, memo, 1, 2)
+	require.Error(t, err)
+
+	// the unlisted product is known without refreshing the rules again
+	_, _, err = mockCli.Order().NewOrdersWithItems(fromInfo, passWd, unlistedItems, memo, 1, 2)
+	require.Error(t, err)
+
+	// the orders fail if the expired rules can't be loaded
+	rulesCache := mockCli.Order().(orderClient).rulesCache
+	rulesCache.now = func() time.Time { return time.Now().Add(productRulesTTL + time.Second) }
+	mockCli.EXPECT().GetCodec().Return(mockCli.GetCodec())
+	mockCli.EXPECT().Query(dextypes.ProductsPath, gomock.Any()).Return(nil, errors.New("default error"))
+	_, _, err = mockCli.Order().NewOrdersWithItems(fromInfo, passWd, orderItems, memo, 1, 2)
+	require.Error(t, err)
+}
+
+func TestOrderClient_CancelOrdersWithIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := sdk.NewClientConfig("testURL", "testChain", sdk.BroadcastBlock, "0.01okt", 200000)
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewOrderClient(mockCli.MockBaseClient))
+
+	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
+	require.NoError(t, err)
+
+	orderIDs := []string{"ID0000000001-1", "ID0000000001-2"}
+	expectedResults := []types.OrderResult{
+		{Code: 0, Message: "", OrderID: orderIDs[0]},
+		{Code: 6, Message: "order not found", OrderID: orderIDs[1]},
+	}
+
+	mockCli.EXPECT().BuildAndBroadcast(fromInfo.GetName(), passWd, memo,
+		[]sdk.Msg{types.NewMsgCancelOrders(fromInfo.GetAddress(), orderIDs)}, uint64(1), uint64(2)).
+		Return(mocks.MockOrdersTxResponse(types.ActionCancelOrders, addr, expectedResults), nil)
+	res, results, err := mockCli.Order().CancelOrdersWithIDs(fromInfo, passWd, orderIDs, memo, 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), res.Code)
+	require.Equal(t, expectedResults, results)
+
+	// malformed events in the response of the broadcasted tx
+	mockCli.EXPECT().BuildAndBroadcast(fromInfo.GetName(), passWd, memo, gomock.Any(), uint64(1), uint64(2)).
+		Return(mocks.MockOrdersTxResponse(types.ActionCancelOrders, "bad sender", expectedResults), nil)
+	_, results, err = mockCli.Order().CancelOrdersWithIDs(fromInfo, passWd, orderIDs, memo, 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(results))
+
+	_, _, err = mockCli.Order().CancelOrdersWithIDs(fromInfo, passWd, nil, memo, 1, 2)
+	require.Error(t, err)
+
+	_, _, err = mockCli.Order().CancelOrdersWithIDs(fromInfo, passWd, []string{orderIDs[0], orderIDs[0]}, memo, 1, 2)
+	require.Error(t, err)
+
+	_, _, err = mockCli.Order().CancelOrdersWithIDs(fromInfo, "", orderIDs, memo, 1, 2)
+	require.Error(t, err)
+}
+
+func TestParseOrderItem(t *testing.T) {
+	orderItem, err := types.ParseOrderItem(product, "sell", "1.024", "10.24")
+	require.NoError(t, err)
+	require.Equal(t, types.SideSell, orderItem.Side)
+	require.Equal(t, types.SideBuy, orderItem.Side.Opposite())
+	require.Equal(t, sdk.MustNewDecFromStr("1.024"), orderItem.Price)
+	require.Equal(t, sdk.MustNewDecFromStr("10.24"), orderItem.Quantity)
+
+	_, err = types.ParseOrderItem(product, "SELL_", "1.024", "10.24")
+	require.Error(t, err)
+
+	_, err = types.ParseOrderItem(product, "BUY", "1.0.24", "10.24")
+	require.Error(t, err)
+
+	_, err = types.ParseOrderItem(product, "BUY", "1.024", "")
+	require.Error(t, err)
+}
+
+func TestOrderClient_CancelOrdersInBatches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := sdk.NewClientConfig("testURL", "testChain", sdk.BroadcastBlock, "0.01okt", 200000)
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewOrderClient(mockCli.MockBaseClient))
+
+	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
+	require.NoError(t, err)
+
+	orderIDs := make([]string, types.MaxOrdersPerMsg+1)
+	for i := range orderIDs {
+		orderIDs[i] = fmt.Sprintf("ID0000000001-%d", i+1)
+	}
+	batchResults := []types.OrderResult{
+		{Code: 0, Message: "", OrderID: orderIDs[0]},
+		{Code: 6, Message: "order not found", OrderID: orderIDs[1]},
+	}
+	for _, orderID := range orderIDs[2:types.MaxOrdersPerMsg] {
+		batchResults = append(batchResults, types.OrderResult{OrderID: orderID})
+	}
+
+	// the last order is in the second tx without events
+	gomock.InOrder(
+		mockCli.EXPECT().BuildAndBroadcast(fromInfo.GetName(), passWd, memo,
+			[]sdk.Msg{types.NewMsgCancelOrders(fromInfo.GetAddress(), orderIDs[:types.MaxOrdersPerMsg])}, uint64(1),
+			uint64(2)).Return(mocks.MockOrdersTxResponse(types.ActionCancelOrders, addr, batchResults), nil),
+		mockCli.EXPECT().BuildAndBroadcast(fromInfo.GetName(), passWd, memo,
+			[]sdk.Msg{types.NewMsgCancelOrders(fromInfo.GetAddress(), orderIDs[types.MaxOrdersPerMsg:])}, uint64(1),
+			uint64(3)).Return(mocks.DefaultMockSuccessTxResponse(), nil),
+	)
+	result, err := mockCli.Order().CancelOrdersInBatches(fromInfo, passWd, orderIDs, memo, 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, types.MaxOrdersPerMsg-1, len(result.Cancelled))
+	require.Equal(t, orderIDs[0], result.Cancelled[0])
+	require.Equal(t, []types.OrderResult{batchResults[1]}, result.Failed)
+	require.Equal(t, orderIDs[types.MaxOrdersPerMsg:], result.Unconfirmed)
+	require.Equal(t, 0, len(result.Unsent))
+
+	// the first tx is broadcasted with an error, which may be committed still
+	gomock.InOrder(
+		mockCli.EXPECT().BuildAndBroadcast(fromInfo.GetName(), passWd, memo, gomock.Any(), uint64(1), uint64(2)).
+			Return(sdk.TxResponse{TxHash: "tx hash 1"}, errors.New("timed out waiting for tx to be included")),
+		mockCli.EXPECT().BuildAndBroadcast(fromInfo.GetName(), passWd, memo, gomock.Any(), uint64(1), uint64(3)).
+			Return(sdk.TxResponse{TxHash: "tx hash 2", Code: 4, RawLog: "unauthorized"}, nil),
+	)
+	result, err = mockCli.Order().CancelOrdersInBatches(fromInfo, passWd, orderIDs, memo, 1, 2)
+	require.Error(t, err)
+	require.Equal(t, []string{"tx hash 1", "tx hash 2"}, result.TxHashes)
+	require.Equal(t, orderIDs[:types.MaxOrdersPerMsg], result.Unconfirmed)
+	require.Equal(t, 1, len(result.Failed))
+	require.Equal(t, uint32(4), result.Failed[0].Code)
+	require.Equal(t, 0, len(result.Unsent))
+
+	// the first tx fails
+	mockCli.EXPECT().BuildAndBroadcast(fromInfo.GetName(), passWd, memo, gomock.Any(), uint64(1), uint64(2)).
+		Return(sdk.TxResponse{}, errors.New("default error"))
+	result, err = mockCli.Order().CancelOrdersInBatches(fromInfo, passWd, orderIDs, memo, 1, 2)
+	require.Error(t, err)
+	require.Equal(t, 0, len(result.Cancelled))
+	require.Equal(t, types.MaxOrdersPerMsg, len(result.Failed))
+	require.Equal(t, orderIDs[types.MaxOrdersPerMsg:], result.Unsent)
+
+	_, err = mockCli.Order().CancelOrdersInBatches(fromInfo, passWd, nil, memo, 1, 2)
+	require.Error(t, err)
+
+	_, err = mockCli.Order().CancelOrdersInBatches(fromInfo, "", orderIDs, memo, 1, 2)
+	require.Error(t, err)
+}
+
+func TestOrderClient_ReplaceOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := sdk.NewClientConfig("testURL", "testChain", sdk.BroadcastBlock, "0.01okt", 200000)
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewOrderClient(mockCli.MockBaseClient))
+
+	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
+	require.NoError(t, err)
+
+	orderIDs := []string{"ID0000000001-1", "ID0000000001-2"}
+	orderItems := []types.OrderItem{
+		types.NewOrderItemDec(product, types.SideBuy, sdk.MustNewDecFromStr("1.025"), sdk.MustNewDecFromStr("10.24")),
+		types.NewOrderItemDec(product, types.SideSell, sdk.MustNewDecFromStr("2.047"), sdk.MustNewDecFromStr("20.48")),
+	}
+	cancelResults := []types.OrderResult{
+		{Code: 0, Message: "", OrderID: orderIDs[0]},
+		{Code: 6, Message: "order not found", OrderID: orderIDs[1]},
+	}
+	newResults := []types.OrderResult{
+		{Code: 0, Message: "", OrderID: "ID0000000002-1"},
+		{Code: 0, Message: "", OrderID: "ID0000000002-2"},
+	}
+
+	// only the new order paired with the cancelled one is placed in the next tx
+	expectProductRules(mockCli)
+	mockCli.EXPECT().BuildAndBroadcast(fromInfo.GetName(), passWd, memo, []sdk.Msg{
+		types.NewMsgCancelOrders(fromInfo.GetAddress(), orderIDs),
+	}, uint64(1), uint64(2)).Return(mocks.MockOrdersTxResponse(types.ActionCancelOrders, addr, cancelResults), nil)
+	mockCli.EXPECT().BuildAndBroadcast(fromInfo.GetName(), passWd, memo, []sdk.Msg{
+		types.NewMsgNewOrders(fromInfo.GetAddress(), orderItems[:1]),
+	}, uint64(1), uint64(3)).Return(mocks.MockOrdersTxResponse(types.ActionNewOrders, addr, newResults[:1]), nil)
+	_, _, results, err := mockCli.Order().ReplaceOrders(fromInfo, passWd, orderIDs, orderItems, memo, 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(results))
+	require.True(t, results[0].IsReplaced())
+	require.Equal(t, "ID0000000002-1", results[0].NewOrderID)
+	require.False(t, results[1].IsReplaced())
+	require.Equal(t, cancelResults[1], results[1].CancelResult)
+	require.Empty(t, results[1].NewOrderID)
+	require.Equal(t, map[string]string{orderIDs[0]: "ID0000000002-1"}, types.GetReplacedOrderIDs(results))
+
+	// no new order is placed without the cancel results
+	mockCli.EXPECT().BuildAndBroadcast(fromInfo.GetName(), passWd, memo, gomock.Any(), uint64(1), uint64(2)).
+		Return(mocks.DefaultMockSuccessTxResponse(), nil)
+	_, _, results, err = mockCli.Order().ReplaceOrders(fromInfo, passWd, orderIDs, orderItems, memo, 1, 2)
+	require.Error(t, err)
+	require.Equal(t, 0, len(results))
+
+	// no new order is placed if none of the old ones is cancelled
+	failedResults := []types.OrderResult{{Code: 6, Message: "order not found", OrderID: orderIDs[0]}, cancelResults[1]}
+	mockCli.EXPECT().BuildAndBroadcast(fromInfo.GetName(), passWd, memo, gomock.Any(), uint64(1), uint64(2)).
+		Return(mocks.MockOrdersTxResponse(types.ActionCancelOrders, addr, failedResults), nil)
+	_, _, results, err = mockCli.Order().ReplaceOrders(fromInfo, passWd, orderIDs, orderItems, memo, 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(types.GetReplacedOrderIDs(results)))
+
+	// the cancel results are kept if placing the new orders fails
+	mockCli.EXPECT().BuildAndBroadcast(fromInfo.GetName(), passWd, memo, gomock.Any(), uint64(1), uint64(2)).
+		Return(mocks.MockOrdersTxResponse(types.ActionCancelOrders, addr, cancelResults), nil)
+	mockCli.EXPECT().BuildAndBroadcast(fromInfo.GetName(), passWd, memo, gomock.Any(), uint64(1), uint64(3)).
+		Return(sdk.TxResponse{}, errors.New("default error"))
+	_, _, results, err = mockCli.Order().ReplaceOrders(fromInfo, passWd, orderIDs, orderItems, memo, 1, 2)
+	require.Error(t, err)
+	require.Equal(t, 2, len(results))
+	require.Equal(t, cancelResults[0], results[0].CancelResult)
+	require.False(t, results[0].IsReplaced())
+
+	_, _, _, err = mockCli.Order().ReplaceOrders(fromInfo, passWd, orderIDs, orderItems[:1], memo, 1, 2)
+	require.Error(t, err)
+
+	_, _, _, err = mockCli.Order().ReplaceOrders(fromInfo, passWd, nil, nil, memo, 1, 2)
+	require.Error(t, err)
+
+	_, _, _, err = mockCli.Order().ReplaceOrders(fromInfo, passWd, []string{orderIDs[0], orderIDs[0]}, orderItems, memo,
+		1, 2)
+	require.Error(t, err)
+
+	_, _, _, err = mockCli.Order().ReplaceOrders(fromInfo, passWd, orderIDs, []types.OrderItem{orderItems[0],
+		types.NewOrderItemDec(product, types.SideSell, sdk.MustNewDecFromStr("2.04701"), sdk.OneDec())}, memo, 1, 2)
+	require.Error(t, err)
+
+	_, _, _, err = mockCli.Order().ReplaceOrders(fromInfo, "", orderIDs, orderItems, memo, 1, 2)
+	require.Error(t, err)
+}
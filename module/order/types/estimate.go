@@ -0,0 +1,103 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	sdk "github.com/okex/okchain-go-sdk/types"
+)
+
+// Params - structure of the params of order module on chain
+type Params struct {
+	OrderExpireBlocks int64       `json:"order_expire_blocks"`
+	MaxDealsPerBlock  int64       `json:"max_deals_per_block"`
+	FeePerBlock       sdk.DecCoin `json:"fee_per_block"`
+	TradeFeeRate      sdk.Dec     `json:"trade_fee_rate"`
+}
+
+// OrderEstimate - structure of the estimated expiry, fees and locked amount of an order item
+type OrderEstimate struct {
+	// ExpireHeight is the height on which the order expires if it rests on the book
+	ExpireHeight int64 `json:"expire_height"`
+	// ExpireTime is the wall-clock time of the expire height estimated by the block interval
+	ExpireTime time.Time `json:"expire_time"`
+	// MaxRestFee is the fee charged per block until the order expires, which is the most on cancellation or expiry
+	MaxRestFee sdk.DecCoin `json:"max_rest_fee"`
+	// MaxTradeFee is the fee charged on the asset received if the order is filled completely
+	MaxTradeFee sdk.DecCoin `json:"max_trade_fee"`
+	// Locked is the quote asset of the price times the quantity for a BUY order, or the base asset of the quantity for
+	// a SELL order
+	Locked sdk.DecCoin `json:"locked"`
+}
+
+// LockedCoin returns the coin locked by placing the order item. A BUY order locks the quote asset of the price times
+// the quantity, while a SELL order locks the base asset of the quantity
+func (oi OrderItem) LockedCoin() (sdk.DecCoin, error) {
+	baseAsset, quoteAsset, err := splitProduct(oi.Product)
+	if err != nil {
+		return sdk.DecCoin{}, err
+	}
+
+	switch oi.Side {
+	case SideBuy:
+		return sdk.DecCoin{Denom: quoteAsset, Amount: oi.Price.Mul(oi.Quantity)}, nil
+	case SideSell:
+		return sdk.DecCoin{Denom: baseAsset, Amount: oi.Quantity}, nil
+	default:
+		return sdk.DecCoin{}, fmt.Errorf("failed. invalid side %s", oi.Side)
+	}
+}
+
+// NewOrderEstimate estimates the expiry, the max fees and the locked amount of the order item placed on the height
+// with the block time and the average block interval
+func NewOrderEstimate(orderItem OrderItem, params Params, height int64, blockTime time.Time,
+	blockInterval time.Duration) (estimate OrderEstimate, err error) {
+	if err = orderItem.Validate(); err != nil {
+		return
+	}
+
+	if params.OrderExpireBlocks <= 0 {
+		return estimate, fmt.Errorf("failed. invalid order expire blocks %d", params.OrderExpireBlocks)
+	}
+
+	if blockInterval <= 0 {
+		return estimate, errors.New("failed. block interval must be positive")
+	}
+
+	if estimate.Locked, err = orderItem.LockedCoin(); err != nil {
+		return
+	}
+
+	baseAsset, quoteAsset, err := splitProduct(orderItem.Product)
+	if err != nil {
+		return
+	}
+
+	estimate.ExpireHeight = height + params.OrderExpireBlocks
+	estimate.ExpireTime = blockTime.Add(time.Duration(params.OrderExpireBlocks) * blockInterval)
+	estimate.MaxRestFee = sdk.DecCoin{
+		Denom:  params.FeePerBlock.Denom,
+		Amount: sdk.DecOrZero(params.FeePerBlock.Amount).MulInt64(params.OrderExpireBlocks),
+	}
+
+	tradeFeeRate := sdk.DecOrZero(params.TradeFeeRate)
+	if orderItem.Side == SideBuy {
+		estimate.MaxTradeFee = sdk.DecCoin{Denom: baseAsset, Amount: orderItem.Quantity.Mul(tradeFeeRate)}
+	} else {
+		estimate.MaxTradeFee = sdk.DecCoin{Denom: quoteAsset, Amount: estimate.Locked.Amount.Mul(orderItem.Price).
+			Mul(tradeFeeRate)}
+	}
+
+	return
+}
+
+func splitProduct(product string) (baseAsset, quoteAsset string, err error) {
+	assets := strings.Split(product, "_")
+	if len(assets) != 2 || len(assets[0]) == 0 || len(assets[1]) == 0 {
+		return baseAsset, quoteAsset, fmt.Errorf("failed. invalid product %s", product)
+	}
+
+	return assets[0], assets[1], nil
+}
@@ -0,0 +1,40 @@
+package types
+
+import (
+	sdk "github.com/okex/okchain-go-sdk/types"
+)
+
+// const of the events emitted by order module
+const (
+	EventTypeMessage = "message"
+	EventTypeFill    = "fill"
+
+	ActionNewOrders    = "new"
+	ActionCancelOrders = "cancel"
+
+	AttributeKeyAction   = "action"
+	AttributeKeySender   = "sender"
+	AttributeKeyOrders   = "orders"
+	AttributeKeyOrderID  = "order_id"
+	AttributeKeyProduct  = "product"
+	AttributeKeySide     = "side"
+	AttributeKeyPrice    = "price"
+	AttributeKeyQuantity = "quantity"
+	AttributeKeyFee      = "fee"
+)
+
+// OrdersEvent - structure of the event emitted by placing or cancelling orders
+type OrdersEvent struct {
+	Sender  sdk.AccAddress `json:"sender"`
+	Results []OrderResult  `json:"orders"`
+}
+
+// FillEvent - structure of the event emitted when an order is filled in the match
+type FillEvent struct {
+	OrderID  string       `json:"order_id"`
+	Product  string       `json:"product"`
+	Side     string       `json:"side"`
+	Price    sdk.Dec      `json:"price"`
+	Quantity sdk.Dec      `json:"quantity"`
+	Fee      sdk.DecCoins `json:"fee"`
+}
@@ -0,0 +1,76 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+
+	dextypes "github.com/okex/okchain-go-sdk/module/dex/types"
+	sdk "github.com/okex/okchain-go-sdk/types"
+)
+
+// ProductRule - structure of the precision rules of a product for placing orders
+type ProductRule struct {
+	Product          string  `json:"product"`
+	MaxPriceDigit    int64   `json:"max_price_digit"`
+	MaxQuantityDigit int64   `json:"max_quantity_digit"`
+	MinQuantity      sdk.Dec `json:"min_quantity"`
+	Delisting        bool    `json:"delisting"`
+}
+
+// NewProductRule creates a new instance of ProductRule from the token pair
+func NewProductRule(tokenPair dextypes.TokenPair) ProductRule {
+	return ProductRule{
+		Product:          fmt.Sprintf("%s_%s", tokenPair.BaseAssetSymbol, tokenPair.QuoteAssetSymbol),
+		MaxPriceDigit:    tokenPair.MaxPriceDigit,
+		MaxQuantityDigit: tokenPair.MaxQuantityDigit,
+		MinQuantity:      tokenPair.MinQuantity,
+		Delisting:        tokenPair.Delisting,
+	}
+}
+
+// Check checks the order item against the rule
+func (pr ProductRule) Check(orderItem OrderItem) error {
+	if pr.Delisting {
+		return fmt.Errorf("failed. product %s is delisting", pr.Product)
+	}
+
+	if !truncateDigits(orderItem.Price, pr.MaxPriceDigit).Equal(orderItem.Price) {
+		return fmt.Errorf("failed. price %s of product %s exceeds the max price digit %d", orderItem.Price,
+			pr.Product, pr.MaxPriceDigit)
+	}
+
+	if !truncateDigits(orderItem.Quantity, pr.MaxQuantityDigit).Equal(orderItem.Quantity) {
+		return fmt.Errorf("failed. quantity %s of product %s exceeds the max quantity digit %d",
+			orderItem.Quantity, pr.Product, pr.MaxQuantityDigit)
+	}
+
+	if !pr.MinQuantity.IsNil() && orderItem.Quantity.LT(pr.MinQuantity) {
+		return fmt.Errorf("failed. quantity %s of product %s is less than the min quantity %s", orderItem.Quantity,
+			pr.Product, pr.MinQuantity)
+	}
+
+	return nil
+}
+
+// Round truncates the price and the quantity of the order item to the allowed digits, and checks the rounded one
+// against the rule. Truncating never makes an order lock more than the original one
+func (pr ProductRule) Round(orderItem OrderItem) (OrderItem, error) {
+	orderItem.Price = truncateDigits(orderItem.Price, pr.MaxPriceDigit)
+	orderItem.Quantity = truncateDigits(orderItem.Quantity, pr.MaxQuantityDigit)
+	if !orderItem.Price.IsPositive() {
+		return orderItem, fmt.Errorf("failed. price of product %s is zero after rounding to %d digits", pr.Product,
+			pr.MaxPriceDigit)
+	}
+
+	return orderItem, pr.Check(orderItem)
+}
+
+// truncateDigits truncates the decimal to the digits after the decimal point
+func truncateDigits(dec sdk.Dec, digits int64) sdk.Dec {
+	if digits < 0 || digits >= sdk.Precision {
+		return dec
+	}
+
+	multiplier := sdk.NewDecFromBigInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(digits), nil))
+	return dec.Mul(multiplier).TruncateDec().Quo(multiplier)
+}
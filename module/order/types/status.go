@@ -0,0 +1,54 @@
+package types
+
+import "fmt"
+
+// OrderStatus shows the state of an order in its lifecycle
+type OrderStatus int64
+
+// const of the order status on chain
+const (
+	OrderStatusOpen                   OrderStatus = 0
+	OrderStatusFilled                 OrderStatus = 1
+	OrderStatusCancelled              OrderStatus = 2
+	OrderStatusExpired                OrderStatus = 3
+	OrderStatusPartialFilledCancelled OrderStatus = 4
+	OrderStatusPartialFilledExpired   OrderStatus = 5
+)
+
+var orderStatusNames = map[OrderStatus]string{
+	OrderStatusOpen:                   "Open",
+	OrderStatusFilled:                 "Filled",
+	OrderStatusCancelled:              "Cancelled",
+	OrderStatusExpired:                "Expired",
+	OrderStatusPartialFilledCancelled: "PartialFilledCancelled",
+	OrderStatusPartialFilledExpired:   "PartialFilledExpired",
+}
+
+// String returns the name of the order status
+func (os OrderStatus) String() string {
+	if name, ok := orderStatusNames[os]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("Unknown(%d)", int64(os))
+}
+
+// IsFinal shows whether the order is no longer on the book
+func (os OrderStatus) IsFinal() bool {
+	return os != OrderStatusOpen
+}
+
+// IsCancelled shows whether the order is cancelled, with or without partial fills
+func (os OrderStatus) IsCancelled() bool {
+	return os == OrderStatusCancelled || os == OrderStatusPartialFilledCancelled
+}
+
+// IsExpired shows whether the order is expired, with or without partial fills
+func (os OrderStatus) IsExpired() bool {
+	return os == OrderStatusExpired || os == OrderStatusPartialFilledExpired
+}
+
+// GetStatus returns the typed status of the order
+func (od OrderDetail) GetStatus() OrderStatus {
+	return OrderStatus(od.Status)
+}
@@ -1,6 +1,10 @@
 package types
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+
 	sdk "github.com/okex/okchain-go-sdk/types"
 )
 
@@ -10,6 +14,10 @@ const (
 
 	DepthbookPath   = "custom/order/depthbook"
 	OrderDetailPath = "custom/order/detail"
+	ParamsPath      = "custom/order/params"
+
+	// MaxOrdersPerMsg is the max number of the order items or order IDs in a msg accepted by the chain
+	MaxOrdersPerMsg = 200
 )
 
 var (
@@ -26,10 +34,48 @@ func RegisterCodec(cdc sdk.SDKCodec) {
 	cdc.RegisterConcrete(MsgCancelOrders{}, "okchain/order/MsgCancel")
 }
 
+// Side shows the direction of an order
+type Side string
+
+// const of the order sides
+const (
+	SideBuy  Side = "BUY"
+	SideSell Side = "SELL"
+)
+
+// ParseSide parses the side string, which is case insensitive, to Side
+func ParseSide(sideStr string) (Side, error) {
+	side := Side(strings.ToUpper(strings.TrimSpace(sideStr)))
+	if !side.IsValid() {
+		return side, fmt.Errorf(`failed. side must only be "BUY" or "SELL" but got %s`, sideStr)
+	}
+
+	return side, nil
+}
+
+// IsValid shows whether the side is BUY or SELL
+func (s Side) IsValid() bool {
+	return s == SideBuy || s == SideSell
+}
+
+// Opposite returns the opposite side
+func (s Side) Opposite() Side {
+	if s == SideBuy {
+		return SideSell
+	}
+
+	return SideBuy
+}
+
+// String returns the side in string
+func (s Side) String() string {
+	return string(s)
+}
+
 // OrderItem - structure for a item in MsgNewOrders
 type OrderItem struct {
 	Product  string  `json:"product"`
-	Side     string  `json:"side"`
+	Side     Side    `json:"side"`
 	Price    sdk.Dec `json:"price"`
 	Quantity sdk.Dec `json:"quantity"`
 }
@@ -38,12 +84,63 @@ type OrderItem struct {
 func NewOrderItem(product string, side string, price string, quantity string) OrderItem {
 	return OrderItem{
 		Product:  product,
-		Side:     side,
+		Side:     Side(side),
 		Price:    sdk.MustNewDecFromStr(price),
 		Quantity: sdk.MustNewDecFromStr(quantity),
 	}
 }
 
+// NewOrderItemDec creates a new instance of OrderItem with the typed side, price and quantity
+func NewOrderItemDec(product string, side Side, price, quantity sdk.Dec) OrderItem {
+	return OrderItem{
+		Product:  product,
+		Side:     side,
+		Price:    price,
+		Quantity: quantity,
+	}
+}
+
+// ParseOrderItem creates a new instance of OrderItem from strings without panic on the invalid input
+func ParseOrderItem(product, sideStr, priceStr, quantityStr string) (orderItem OrderItem, err error) {
+	side, err := ParseSide(sideStr)
+	if err != nil {
+		return
+	}
+
+	price, err := sdk.NewDecFromStr(priceStr)
+	if err != nil {
+		return orderItem, fmt.Errorf("failed. parse price %s error: %s", priceStr, err)
+	}
+
+	quantity, err := sdk.NewDecFromStr(quantityStr)
+	if err != nil {
+		return orderItem, fmt.Errorf("failed. parse quantity %s error: %s", quantityStr, err)
+	}
+
+	return NewOrderItemDec(product, side, price, quantity), nil
+}
+
+// Validate gives a quick validity check for the order item
+func (oi OrderItem) Validate() error {
+	if len(oi.Product) == 0 {
+		return errors.New("failed. empty product")
+	}
+
+	if !oi.Side.IsValid() {
+		return fmt.Errorf(`failed. side must only be "BUY" or "SELL" but got %s`, oi.Side)
+	}
+
+	if oi.Price.IsNil() || !oi.Price.IsPositive() {
+		return fmt.Errorf("failed. price of %s must be positive", oi.Product)
+	}
+
+	if oi.Quantity.IsNil() || !oi.Quantity.IsPositive() {
+		return fmt.Errorf("failed. quantity of %s must be positive", oi.Product)
+	}
+
+	return nil
+}
+
 // BuildOrderItems returns the set of OrderItem
 // params must be checked by function CheckNewOrderParams
 func BuildOrderItems(products, sides, prices, quantities []string) []OrderItem {
@@ -63,6 +160,46 @@ type OrderResult struct {
 	OrderID string `json:"orderid"`
 }
 
+// ReplaceResult - structure of the result of replacing an order with a new one
+type ReplaceResult struct {
+	OldOrderID   string      `json:"old_order_id"`
+	NewOrderID   string      `json:"new_order_id"`
+	CancelResult OrderResult `json:"cancel_result"`
+	NewResult    OrderResult `json:"new_result"`
+}
+
+// IsReplaced shows whether the old order is cancelled and the new one is placed. The new order is never placed with the
+// old one left live
+func (rr ReplaceResult) IsReplaced() bool {
+	return rr.CancelResult.Code == 0 && rr.NewResult.Code == 0 && len(rr.NewOrderID) != 0
+}
+
+// GetReplacedOrderIDs maps the old order IDs to the new ones of the orders replaced successfully
+func GetReplacedOrderIDs(results []ReplaceResult) map[string]string {
+	orderIDs := make(map[string]string, len(results))
+	for _, result := range results {
+		if result.IsReplaced() {
+			orderIDs[result.OldOrderID] = result.NewOrderID
+		}
+	}
+
+	return orderIDs
+}
+
+// CancelOrdersResult - structure of the results of cancelling orders in batches
+type CancelOrdersResult struct {
+	// Cancelled is the IDs of the orders cancelled successfully
+	Cancelled []string `json:"cancelled"`
+	// Failed is the results of the orders failed to cancel, including the ones in a rejected tx
+	Failed []OrderResult `json:"failed"`
+	// Unconfirmed is the IDs of the orders broadcasted without results, such as in async or sync mode
+	Unconfirmed []string `json:"unconfirmed"`
+	// Unsent is the IDs of the orders not broadcasted because of a failed batch before
+	Unsent []string `json:"unsent"`
+	// TxHashes is the hashes of the txs broadcasted
+	TxHashes []string `json:"tx_hashes"`
+}
+
 // BookRes - structure of depthbook
 type BookRes struct {
 	Asks []BookResItem `json:"asks"`
@@ -5,10 +5,31 @@ import "github.com/okex/okchain-go-sdk/module/staking/types"
 // const
 const (
 	ModuleName = types.ModuleName
+
+	BondStatusUnbonded  = types.BondStatusUnbonded
+	BondStatusUnbonding = types.BondStatusUnbonding
+	BondStatusBonded    = types.BondStatusBonded
+
+	JailStatusAny       = types.JailStatusAny
+	JailStatusJailed    = types.JailStatusJailed
+	JailStatusNotJailed = types.JailStatusNotJailed
+)
+
+var (
+	// NewValidatorViews is the alias of the one under staking/types
+	NewValidatorViews = types.NewValidatorViews
+	// SortValidatorsByShares is the alias of the one under staking/types
+	SortValidatorsByShares = types.SortValidatorsByShares
 )
 
 type (
 	// nolint
-	Validator     = types.Validator
-	DelegatorResp = types.DelegatorResp
+	Validator       = types.Validator
+	DelegatorResp   = types.DelegatorResp
+	DelegateEvent   = types.DelegateEvent
+	UnbondEvent     = types.UnbondEvent
+	BondStatus      = types.BondStatus
+	JailStatus      = types.JailStatus
+	ValidatorFilter = types.ValidatorFilter
+	ValidatorView   = types.ValidatorView
 )
@@ -17,7 +17,10 @@ func (sc stakingClient) QueryValidators() (vals []types.Validator, err error) {
 
 	for _, kv := range resKVs {
 		var innerVal types.ValidatorInner
-		sc.GetCodec().MustUnmarshalBinaryLengthPrefixed(kv.Value, &innerVal)
+		if err = sc.GetCodec().UnmarshalBinaryLengthPrefixed(kv.Value, &innerVal); err != nil {
+			return nil, utils.ErrUnmarshalJSON(err.Error())
+		}
+
 		val, err := innerVal.Standardize()
 		if err != nil {
 			return nil, err
@@ -29,6 +32,17 @@ func (sc stakingClient) QueryValidators() (vals []types.Validator, err error) {
 
 }
 
+// QueryValidatorViews gets the complete views of the validators meeting the filter with their voting power share,
+// which are sorted by the delegator shares in descending order
+func (sc stakingClient) QueryValidatorViews(filter types.ValidatorFilter) (views []types.ValidatorView, err error) {
+	vals, err := sc.QueryValidators()
+	if err != nil {
+		return
+	}
+
+	return types.NewValidatorViews(vals, filter), nil
+}
+
 // QueryValidator gets the info of a specific validator
 func (sc stakingClient) QueryValidator(valAddrStr string) (val types.Validator, err error) {
 	valAddr, err := sdk.ValAddressFromBech32(valAddrStr)
@@ -45,7 +59,9 @@ func (sc stakingClient) QueryValidator(valAddrStr string) (val types.Validator,
 	}
 
 	var innerVal types.ValidatorInner
-	sc.GetCodec().MustUnmarshalBinaryLengthPrefixed(res, &innerVal)
+	if err = sc.GetCodec().UnmarshalBinaryLengthPrefixed(res, &innerVal); err != nil {
+		return val, utils.ErrUnmarshalJSON(err.Error())
+	}
 
 	return innerVal.Standardize()
 
@@ -65,7 +81,9 @@ func (sc stakingClient) QueryDelegator(delAddrStr string) (delResp types.Delegat
 
 	delegator, undelegation := types.NewDelegator(delAddr), types.DefaultUndelegation()
 	if len(resp) != 0 {
-		sc.GetCodec().MustUnmarshalBinaryLengthPrefixed(resp, &delegator)
+		if err = sc.GetCodec().UnmarshalBinaryLengthPrefixed(resp, &delegator); err != nil {
+			return delResp, utils.ErrUnmarshalJSON(err.Error())
+		}
 	}
 
 	// query for the undelegation info
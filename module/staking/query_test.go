@@ -74,6 +74,100 @@ func TestStakingClient_QueryValidators(t *testing.T) {
 	mockCli.EXPECT().QuerySubspace(types.ValidatorsKey, types.ModuleName).Return(expectedRet, errors.New("default error"))
 	_, err = mockCli.Staking().QueryValidators()
 	require.Error(t, err)
+	// bad data returns an error without panic
+	mockCli.EXPECT().GetCodec().Return(expectedCdc)
+	mockCli.EXPECT().QuerySubspace(types.ValidatorsKey, types.ModuleName).Return([]cmn.KVPair{
+		{Key: expectedRet[0].Key, Value: []byte{1}},
+	}, nil)
+	_, err = mockCli.Staking().QueryValidators()
+	require.Error(t, err)
+}
+
+func TestStakingClient_QueryValidatorViews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := sdk.NewClientConfig("testURL", "testChain", sdk.BroadcastBlock, "0.01okt", 200000)
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewStakingClient(mockCli.MockBaseClient))
+
+	consPK, err := sdk.GetConsPubKeyBech32(valConsPK)
+	require.NoError(t, err)
+	commission := types.Commission{
+		CommissionRates: types.CommissionRates{
+			Rate:          sdk.MustNewDecFromStr("0.1"),
+			MaxRate:       sdk.MustNewDecFromStr("0.2"),
+			MaxChangeRate: sdk.MustNewDecFromStr("0.01"),
+		},
+		UpdateTime: time.Unix(1024, 0).UTC(),
+	}
+	expectedCdc := mockCli.GetCodec()
+	newKVPair := func(addrByte byte, status types.BondStatus, jailed bool, shares string, tokens int64) cmn.KVPair {
+		valOperAddr := sdk.ValAddress(append(make([]byte, 19), addrByte))
+		return cmn.KVPair{
+			Key: types.GetValidatorKey(valOperAddr),
+			Value: expectedCdc.MustMarshalBinaryLengthPrefixed(types.ValidatorInner{
+				OperatorAddress:   valOperAddr,
+				ConsPubKey:        consPK,
+				Jailed:            jailed,
+				Status:            byte(status),
+				Tokens:            sdk.NewInt(tokens),
+				DelegatorShares:   sdk.MustNewDecFromStr(shares),
+				Commission:        commission,
+				MinSelfDelegation: sdk.MustNewDecFromStr("0.001"),
+			}),
+		}
+	}
+	kvPairs := []cmn.KVPair{
+		newKVPair(1, types.BondStatusBonded, false, "1", 300),
+		newKVPair(2, types.BondStatusUnbonded, false, "2", 200),
+		newKVPair(3, types.BondStatusBonded, true, "4", 400),
+		newKVPair(4, types.BondStatusBonded, false, "3", 100),
+	}
+
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).Times(8)
+	mockCli.EXPECT().QuerySubspace(types.ValidatorsKey, types.ModuleName).Return(kvPairs, nil).Times(2)
+
+	views, err := mockCli.Staking().QueryValidatorViews(types.ValidatorFilter{})
+	require.NoError(t, err)
+	require.Equal(t, 4, len(views))
+	// sorted by shares with the voting power share by tokens over the bonded validators out of jail
+	require.Equal(t, byte(3), views[0].OperatorAddress[19])
+	require.True(t, views[0].VotingPowerShare.IsZero())
+	require.Equal(t, byte(4), views[1].OperatorAddress[19])
+	require.Equal(t, sdk.MustNewDecFromStr("0.25"), views[1].VotingPowerShare)
+	require.Equal(t, types.BondStatusUnbonded, views[2].GetStatus())
+	require.Equal(t, sdk.MustNewDecFromStr("0.75"), views[3].VotingPowerShare)
+	require.Equal(t, sdk.NewInt(300), views[3].Tokens)
+	require.Equal(t, commission.Rate, views[3].Commission.Rate)
+	require.True(t, commission.UpdateTime.Equal(views[3].Commission.UpdateTime))
+	require.Equal(t, "Bonded", views[3].GetStatus().String())
+
+	views, err = mockCli.Staking().QueryValidatorViews(types.ValidatorFilter{
+		Statuses:   []types.BondStatus{types.BondStatusBonded},
+		JailStatus: types.JailStatusNotJailed,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(views))
+	require.Equal(t, byte(4), views[0].OperatorAddress[19])
+	require.Equal(t, byte(1), views[1].OperatorAddress[19])
+
+	mockCli.EXPECT().QuerySubspace(types.ValidatorsKey, types.ModuleName).Return(nil, errors.New("default error"))
+	_, err = mockCli.Staking().QueryValidatorViews(types.ValidatorFilter{})
+	require.Error(t, err)
+}
+
+func TestSortValidatorsByShares(t *testing.T) {
+	vals := []types.Validator{
+		{OperatorAddress: sdk.ValAddress{2}, DelegatorShares: sdk.OneDec()},
+		{OperatorAddress: sdk.ValAddress{3}},
+		{OperatorAddress: sdk.ValAddress{1}, DelegatorShares: sdk.OneDec()},
+		{OperatorAddress: sdk.ValAddress{4}, DelegatorShares: sdk.MustNewDecFromStr("2")},
+	}
+	types.SortValidatorsByShares(vals)
+	require.Equal(t, []sdk.ValAddress{{4}, {1}, {2}, {3}}, []sdk.ValAddress{vals[0].OperatorAddress,
+		vals[1].OperatorAddress, vals[2].OperatorAddress, vals[3].OperatorAddress})
+	require.Equal(t, "Unknown(9)", types.BondStatus(9).String())
 }
 
 func TestStakingClient_QueryValidator(t *testing.T) {
@@ -129,6 +223,12 @@ func TestStakingClient_QueryValidator(t *testing.T) {
 	_, err = mockCli.Staking().QueryValidator(valAddr)
 	require.Error(t, err)
 
+	mockCli.EXPECT().GetCodec().Return(expectedCdc)
+	mockCli.EXPECT().QueryStore(cmn.HexBytes(types.GetValidatorKey(valOperAddr)), ModuleName, "key").
+		Return([]byte{1}, nil)
+	_, err = mockCli.Staking().QueryValidator(valAddr)
+	require.Error(t, err)
+
 }
 
 func TestStakingClient_QueryDelegator(t *testing.T) {
@@ -0,0 +1,30 @@
+package types
+
+import (
+	"time"
+
+	sdk "github.com/okex/okchain-go-sdk/types"
+)
+
+// const of the events emitted by staking module
+const (
+	EventTypeDelegate = "delegate"
+	EventTypeUnbond   = "unbond"
+
+	AttributeKeyDelegator      = "delegator"
+	AttributeKeyAmount         = "amount"
+	AttributeKeyCompletionTime = "completion_time"
+)
+
+// DelegateEvent - structure of the event emitted by a delegation
+type DelegateEvent struct {
+	Delegator sdk.AccAddress `json:"delegator"`
+	Amount    sdk.DecCoin    `json:"amount"`
+}
+
+// UnbondEvent - structure of the event emitted by an undelegation
+type UnbondEvent struct {
+	Delegator      sdk.AccAddress `json:"delegator"`
+	Amount         sdk.DecCoin    `json:"amount"`
+	CompletionTime time.Time      `json:"completion_time"`
+}
@@ -67,10 +67,12 @@ func (vi ValidatorInner) Standardize() (val Validator, err error) {
 		ConsPubKey:              bechConsPubKey,
 		Jailed:                  vi.Jailed,
 		Status:                  vi.Status,
+		Tokens:                  vi.Tokens,
 		DelegatorShares:         vi.DelegatorShares,
 		Description:             vi.Description,
 		UnbondingHeight:         vi.UnbondingHeight,
 		UnbondingCompletionTime: vi.UnbondingCompletionTime,
+		Commission:              vi.Commission,
 		MinSelfDelegation:       vi.MinSelfDelegation,
 	}, err
 }
@@ -167,10 +169,12 @@ type Validator struct {
 	ConsPubKey              string         `json:"consensus_pubkey"`
 	Jailed                  bool           `json:"jailed"`
 	Status                  byte           `json:"status"`
+	Tokens                  sdk.Int        `json:"tokens"`
 	DelegatorShares         sdk.Dec        `json:"delegator_shares"`
 	Description             Description    `json:"description"`
 	UnbondingHeight         int64          `json:"unbonding_height"`
 	UnbondingCompletionTime time.Time      `json:"unbonding_time"`
+	Commission              Commission     `json:"commission"`
 	MinSelfDelegation       sdk.Dec        `json:"min_self_delegation"`
 }
 
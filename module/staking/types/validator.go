@@ -0,0 +1,144 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	sdk "github.com/okex/okchain-go-sdk/types"
+)
+
+// BondStatus shows the bonding state of a validator
+type BondStatus byte
+
+// const of the validator bond status on chain
+const (
+	BondStatusUnbonded  BondStatus = 0
+	BondStatusUnbonding BondStatus = 1
+	BondStatusBonded    BondStatus = 2
+)
+
+var bondStatusNames = map[BondStatus]string{
+	BondStatusUnbonded:  "Unbonded",
+	BondStatusUnbonding: "Unbonding",
+	BondStatusBonded:    "Bonded",
+}
+
+// String returns the name of the bond status
+func (bs BondStatus) String() string {
+	if name, ok := bondStatusNames[bs]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("Unknown(%d)", byte(bs))
+}
+
+// JailStatus shows whether a validator is jailed, which is used to filter validators
+type JailStatus int
+
+// const of the jail status filter
+const (
+	JailStatusAny JailStatus = iota
+	JailStatusJailed
+	JailStatusNotJailed
+)
+
+// GetStatus returns the typed bond status of the validator
+func (v Validator) GetStatus() BondStatus {
+	return BondStatus(v.Status)
+}
+
+// GetJailStatus returns the jail status of the validator
+func (v Validator) GetJailStatus() JailStatus {
+	if v.Jailed {
+		return JailStatusJailed
+	}
+
+	return JailStatusNotJailed
+}
+
+// HasVotingPower shows whether the shares of the validator count in the voting power, which only bonded validators out
+// of jail have
+func (v Validator) HasVotingPower() bool {
+	return v.GetStatus() == BondStatusBonded && !v.Jailed
+}
+
+// ValidatorFilter - structure of the conditions to filter validators. An empty Statuses matches all the bond status
+type ValidatorFilter struct {
+	Statuses   []BondStatus
+	JailStatus JailStatus
+}
+
+// Match shows whether the validator meets the filter
+func (vf ValidatorFilter) Match(val Validator) bool {
+	if vf.JailStatus != JailStatusAny && vf.JailStatus != val.GetJailStatus() {
+		return false
+	}
+
+	if len(vf.Statuses) == 0 {
+		return true
+	}
+
+	for _, status := range vf.Statuses {
+		if status == val.GetStatus() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ValidatorView - structure of the complete view of a validator with its share of the total voting power
+type ValidatorView struct {
+	Validator
+	// VotingPowerShare is the bonded tokens of the validator over the ones of all the validators with voting power,
+	// which is zero for the ones without voting power
+	VotingPowerShare sdk.Dec `json:"voting_power_share"`
+}
+
+// NewValidatorViews creates the views of the validators meeting the filter, which are sorted by the delegator shares
+// in descending order. The voting power share is computed by the tokens over all the validators input
+func NewValidatorViews(vals []Validator, filter ValidatorFilter) []ValidatorView {
+	totalTokens := sdk.ZeroDec()
+	for _, val := range vals {
+		if val.HasVotingPower() && !val.Tokens.IsNil() {
+			totalTokens = totalTokens.Add(sdk.NewDecFromInt(val.Tokens))
+		}
+	}
+
+	var views []ValidatorView
+	for _, val := range vals {
+		if !filter.Match(val) {
+			continue
+		}
+
+		view := ValidatorView{Validator: val, VotingPowerShare: sdk.ZeroDec()}
+		if val.HasVotingPower() && !val.Tokens.IsNil() && totalTokens.IsPositive() {
+			view.VotingPowerShare = sdk.NewDecFromInt(val.Tokens).Quo(totalTokens)
+		}
+		views = append(views, view)
+	}
+
+	sort.SliceStable(views, func(i, j int) bool {
+		return lessByShares(views[i].Validator, views[j].Validator)
+	})
+
+	return views
+}
+
+// SortValidatorsByShares sorts the validators by the delegator shares in descending order, and by the operator address
+// for the equal shares
+func SortValidatorsByShares(vals []Validator) {
+	sort.SliceStable(vals, func(i, j int) bool {
+		return lessByShares(vals[i], vals[j])
+	})
+}
+
+func lessByShares(vi, vj Validator) bool {
+	sharesI, sharesJ := sdk.DecOrZero(vi.DelegatorShares), sdk.DecOrZero(vj.DelegatorShares)
+	if !sharesI.Equal(sharesJ) {
+		return sharesI.GT(sharesJ)
+	}
+
+	return bytes.Compare(vi.OperatorAddress, vj.OperatorAddress) < 0
+}
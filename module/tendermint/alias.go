@@ -5,14 +5,23 @@ import "github.com/okex/okchain-go-sdk/module/tendermint/types"
 // const
 const (
 	ModuleName = types.ModuleName
+
+	MaxTxsPerPage = types.MaxTxsPerPage
 )
 
 type (
 	// nolint
 	Block            = types.Block
 	BlockResults     = types.BlockResults
+	BlockWithResults = types.BlockWithResults
 	ResultCommit     = types.ResultCommit
 	ResultValidators = types.ResultValidators
 	ResultTx         = types.ResultTx
 	ResultTxs        = types.ResultTxs
+	TxQuery          = types.TxQuery
+)
+
+var (
+	// NewTxQuery is the alias of the one under tendermint/types
+	NewTxQuery = types.NewTxQuery
 )
@@ -0,0 +1,250 @@
+package tendermint
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/okex/okchain-go-sdk/exposed"
+	"github.com/okex/okchain-go-sdk/module/tendermint/types"
+)
+
+const (
+	defaultIteratorWorkers      = 4
+	defaultIteratorPollInterval = time.Second
+)
+
+// BlockIteratorConfig - structure of the config for BlockIterator
+type BlockIteratorConfig struct {
+	// From is the first height to fetch, inclusive
+	From int64
+	// To is the last height to fetch, inclusive. A non-positive value means the latest height of the chain
+	To int64
+	// Workers limits the number of blocks fetched concurrently
+	Workers int
+	// Follow keeps the iterator waiting for new blocks once the latest height is reached. It only works when To is
+	// non-positive
+	Follow bool
+	// PollInterval is the interval to query the latest height while following the chain head
+	PollInterval time.Duration
+}
+
+// NewBlockIteratorConfig creates a new instance of BlockIteratorConfig with the default workers and poll interval
+func NewBlockIteratorConfig(from, to int64, follow bool) BlockIteratorConfig {
+	return BlockIteratorConfig{
+		From:         from,
+		To:           to,
+		Workers:      defaultIteratorWorkers,
+		Follow:       follow,
+		PollInterval: defaultIteratorPollInterval,
+	}
+}
+
+// ResumeFrom returns a copy of the config that starts right after the checkpoint height
+func (bic BlockIteratorConfig) ResumeFrom(checkpoint int64) BlockIteratorConfig {
+	bic.From = checkpoint + 1
+	return bic
+}
+
+type fetchResult struct {
+	item types.BlockWithResults
+	err  error
+}
+
+type fetchJob struct {
+	height int64
+	res    chan fetchResult
+}
+
+// BlockIterator fetches the blocks and their results over a height range with a bounded worker pool and yields them
+// in height order
+type BlockIterator struct {
+	tq     exposed.TendermintQuery
+	config BlockIteratorConfig
+
+	pending chan fetchJob
+	jobs    chan fetchJob
+	quit    chan struct{}
+	once    sync.Once
+
+	current    types.BlockWithResults
+	checkpoint int64
+	err        error
+}
+
+// NewBlockIterator creates a new instance of BlockIterator and starts fetching in the background
+func NewBlockIterator(tq exposed.TendermintQuery, config BlockIteratorConfig) (*BlockIterator, error) {
+	if config.From <= 0 {
+		return nil, errors.New("failed. the start height must be greater than 0")
+	}
+
+	if config.To > 0 && config.To < config.From {
+		return nil, fmt.Errorf("failed. the end height %d is less than the start height %d", config.To, config.From)
+	}
+
+	if config.Workers <= 0 {
+		config.Workers = defaultIteratorWorkers
+	}
+
+	if config.PollInterval <= 0 {
+		config.PollInterval = defaultIteratorPollInterval
+	}
+
+	bi := &BlockIterator{
+		tq:         tq,
+		config:     config,
+		pending:    make(chan fetchJob, config.Workers*2),
+		jobs:       make(chan fetchJob),
+		quit:       make(chan struct{}),
+		checkpoint: config.From - 1,
+	}
+
+	for i := 0; i < config.Workers; i++ {
+		go bi.work()
+	}
+	go bi.dispatch()
+
+	return bi, nil
+}
+
+// Next fetches the next block in height order. It returns false when the range is exhausted, an error occurs or the
+// iterator is closed
+func (bi *BlockIterator) Next() bool {
+	if bi.err != nil {
+		return false
+	}
+
+	var job fetchJob
+	var ok bool
+	select {
+	case job, ok = <-bi.pending:
+		if !ok {
+			return false
+		}
+	case <-bi.quit:
+		return false
+	}
+
+	select {
+	case res := <-job.res:
+		if res.err != nil {
+			bi.err = res.err
+			bi.Close()
+			return false
+		}
+		bi.current = res.item
+		bi.checkpoint = job.height
+		return true
+	case <-bi.quit:
+		return false
+	}
+}
+
+// Value returns the block fetched by the last call of Next
+func (bi *BlockIterator) Value() types.BlockWithResults {
+	return bi.current
+}
+
+// Err returns the error that stops the iteration
+func (bi *BlockIterator) Err() error {
+	return bi.err
+}
+
+// Checkpoint returns the height of the last block yielded. It is used to resume with BlockIteratorConfig.ResumeFrom
+func (bi *BlockIterator) Checkpoint() int64 {
+	return bi.checkpoint
+}
+
+// Close stops the background fetching
+func (bi *BlockIterator) Close() {
+	bi.once.Do(func() {
+		close(bi.quit)
+	})
+}
+
+func (bi *BlockIterator) dispatch() {
+	defer close(bi.pending)
+	defer close(bi.jobs)
+
+	head, err := bi.head()
+	if err != nil {
+		bi.pushError(err)
+		return
+	}
+
+	for height := bi.config.From; ; height++ {
+		for height > head {
+			if bi.config.To > 0 || !bi.config.Follow {
+				return
+			}
+
+			select {
+			case <-time.After(bi.config.PollInterval):
+			case <-bi.quit:
+				return
+			}
+
+			if head, err = bi.tq.QueryLatestHeight(); err != nil {
+				bi.pushError(err)
+				return
+			}
+		}
+
+		job := fetchJob{height: height, res: make(chan fetchResult, 1)}
+		select {
+		case bi.pending <- job:
+		case <-bi.quit:
+			return
+		}
+
+		select {
+		case bi.jobs <- job:
+		case <-bi.quit:
+			return
+		}
+	}
+}
+
+func (bi *BlockIterator) head() (int64, error) {
+	if bi.config.To > 0 {
+		return bi.config.To, nil
+	}
+
+	return bi.tq.QueryLatestHeight()
+}
+
+func (bi *BlockIterator) pushError(err error) {
+	job := fetchJob{res: make(chan fetchResult, 1)}
+	job.res <- fetchResult{err: err}
+	select {
+	case bi.pending <- job:
+	case <-bi.quit:
+	}
+}
+
+func (bi *BlockIterator) work() {
+	for job := range bi.jobs {
+		job.res <- bi.fetch(job.height)
+	}
+}
+
+func (bi *BlockIterator) fetch(height int64) (res fetchResult) {
+	block, err := bi.tq.QueryBlock(height)
+	if err != nil {
+		res.err = fmt.Errorf("failed. query block on height %d error: %s", height, err)
+		return
+	}
+
+	results, err := bi.tq.QueryBlockResults(height)
+	if err != nil {
+		res.err = fmt.Errorf("failed. query block results on height %d error: %s", height, err)
+		return
+	}
+
+	res.item = types.BlockWithResults{
+		Block:   block,
+		Results: results,
+	}
+	return
+}
@@ -0,0 +1,120 @@
+package tendermint
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/okex/okchain-go-sdk/mocks"
+	sdk "github.com/okex/okchain-go-sdk/types"
+	"github.com/stretchr/testify/require"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+)
+
+func TestBlockIterator(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := sdk.NewClientConfig("testURL", "testChain", sdk.BroadcastBlock, "0.01okt", 200000)
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewTendermintClient(mockCli.MockBaseClient))
+
+	expectedCdc := mockCli.GetCodec()
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).AnyTimes()
+	mockCli.EXPECT().Block(gomock.Any()).DoAndReturn(func(height *int64) (*ctypes.ResultBlock, error) {
+		return mockCli.GetRawResultBlockPointer("default chainID", *height, time.Now(), cmn.HexBytes("app hash"),
+			cmn.HexBytes("block ID hash")), nil
+	}).AnyTimes()
+	mockCli.EXPECT().BlockResults(gomock.Any()).DoAndReturn(func(height *int64) (*ctypes.ResultBlockResults, error) {
+		return mockCli.GetRawResultBlockResultsPointer(1, *height, "pubkey type", "event type",
+			[]byte("kv pair key")), nil
+	}).AnyTimes()
+
+	// fixed range
+	iterator, err := NewBlockIterator(mockCli.Tendermint(), NewBlockIteratorConfig(3, 20, false))
+	require.NoError(t, err)
+	expectedHeight := int64(3)
+	for iterator.Next() {
+		require.Equal(t, expectedHeight, iterator.Value().Block.Height)
+		require.Equal(t, expectedHeight, iterator.Value().Results.Height)
+		require.Equal(t, expectedHeight, iterator.Checkpoint())
+		expectedHeight++
+	}
+	require.NoError(t, iterator.Err())
+	require.Equal(t, int64(21), expectedHeight)
+
+	// resume from the checkpoint to the latest height
+	mockCli.EXPECT().Commit(nil).Return(mockCli.GetRawCommitResultPointer(true, "default chainID", 25, time.Now(),
+		cmn.HexBytes("app hash"), cmn.HexBytes("block ID hash")), nil)
+	iterator, err = NewBlockIterator(mockCli.Tendermint(), NewBlockIteratorConfig(3, 0, false).ResumeFrom(20))
+	require.NoError(t, err)
+	for iterator.Next() {
+		require.Equal(t, expectedHeight, iterator.Value().Block.Height)
+		expectedHeight++
+	}
+	require.NoError(t, iterator.Err())
+	require.Equal(t, int64(26), expectedHeight)
+	require.Equal(t, int64(25), iterator.Checkpoint())
+
+	// follow the chain head
+	gomock.InOrder(
+		mockCli.EXPECT().Commit(nil).Return(mockCli.GetRawCommitResultPointer(true, "default chainID", 26,
+			time.Now(), cmn.HexBytes("app hash"), cmn.HexBytes("block ID hash")), nil),
+		mockCli.EXPECT().Commit(nil).Return(mockCli.GetRawCommitResultPointer(true, "default chainID", 28,
+			time.Now(), cmn.HexBytes("app hash"), cmn.HexBytes("block ID hash")), nil),
+		mockCli.EXPECT().Commit(nil).Return(nil, errors.New("default error")),
+	)
+	iteratorConfig := NewBlockIteratorConfig(26, 0, true)
+	iteratorConfig.PollInterval = time.Millisecond
+	iterator, err = NewBlockIterator(mockCli.Tendermint(), iteratorConfig)
+	require.NoError(t, err)
+	for iterator.Next() {
+		require.Equal(t, expectedHeight, iterator.Value().Block.Height)
+		expectedHeight++
+	}
+	require.Error(t, iterator.Err())
+	require.Equal(t, int64(29), expectedHeight)
+	require.Equal(t, int64(28), iterator.Checkpoint())
+
+	// bad config
+	_, err = NewBlockIterator(mockCli.Tendermint(), NewBlockIteratorConfig(0, 20, false))
+	require.Error(t, err)
+	_, err = NewBlockIterator(mockCli.Tendermint(), NewBlockIteratorConfig(20, 3, false))
+	require.Error(t, err)
+}
+
+func TestBlockIterator_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := sdk.NewClientConfig("testURL", "testChain", sdk.BroadcastBlock, "0.01okt", 200000)
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewTendermintClient(mockCli.MockBaseClient))
+
+	expectedCdc := mockCli.GetCodec()
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).AnyTimes()
+	mockCli.EXPECT().Block(gomock.Any()).DoAndReturn(func(height *int64) (*ctypes.ResultBlock, error) {
+		if *height == 5 {
+			return nil, errors.New("default error")
+		}
+		return mockCli.GetRawResultBlockPointer("default chainID", *height, time.Now(), cmn.HexBytes("app hash"),
+			cmn.HexBytes("block ID hash")), nil
+	}).AnyTimes()
+	mockCli.EXPECT().BlockResults(gomock.Any()).DoAndReturn(func(height *int64) (*ctypes.ResultBlockResults, error) {
+		return mockCli.GetRawResultBlockResultsPointer(1, *height, "pubkey type", "event type",
+			[]byte("kv pair key")), nil
+	}).AnyTimes()
+
+	iterator, err := NewBlockIterator(mockCli.Tendermint(), NewBlockIteratorConfig(1, 10, false))
+	require.NoError(t, err)
+	var count int
+	for iterator.Next() {
+		count++
+	}
+	require.Error(t, iterator.Err())
+	require.Equal(t, 4, count)
+	require.Equal(t, int64(4), iterator.Checkpoint())
+	require.False(t, iterator.Next())
+}
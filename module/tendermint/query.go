@@ -49,6 +49,16 @@ func (tc tendermintClient) QueryValidatorsResult(height int64) (valsResult types
 	return utils.ParseValidatorsResult(pTmValsResult), err
 }
 
+// QueryLatestHeight gets the height of the latest committed block
+func (tc tendermintClient) QueryLatestHeight() (height int64, err error) {
+	pTmCommitResult, err := tc.Commit(nil)
+	if err != nil {
+		return
+	}
+
+	return pTmCommitResult.Header.Height, err
+}
+
 // QueryTxResult gets the detail info of a tx with its tx hash
 func (tc tendermintClient) QueryTxResult(txHash []byte, prove bool) (txResult types.ResultTx, err error) {
 	pTmTxResult, err := tc.Tx(txHash, prove)
@@ -83,6 +93,43 @@ func (tc tendermintClient) QueryTxsResult(searchStr string, page, perPage int) (
 	return utils.ParseTxsResult(pTmTxsResult), err
 }
 
+// QueryTxsResultByQuery gets txs result by a query built with TxQuery
+// NOTE: QueryTxsResultByQuery assumes the node telling truth
+func (tc tendermintClient) QueryTxsResultByQuery(query types.TxQuery, page, perPage int) (txsResult types.ResultTxs,
+	err error) {
+	queryStr, err := query.Build()
+	if err != nil {
+		return
+	}
+
+	if err = params.CheckQueryTxResultParams([]string{queryStr}, page, perPage); err != nil {
+		return
+	}
+
+	pTmTxsResult, err := tc.TxSearch(queryStr, false, page, perPage)
+	if err != nil {
+		return
+	}
+
+	return utils.ParseTxsResult(pTmTxsResult), err
+}
+
+// QueryAllTxsResult gets all the txs result by a query built with TxQuery, turning pages over the total count
+// NOTE: QueryAllTxsResult assumes the node telling truth
+func (tc tendermintClient) QueryAllTxsResult(query types.TxQuery) (txs []types.ResultTx, err error) {
+	for page := 1; ; page++ {
+		txsResult, err := tc.QueryTxsResultByQuery(query, page, types.MaxTxsPerPage)
+		if err != nil {
+			return nil, err
+		}
+
+		txs = append(txs, txsResult.Txs...)
+		if len(txsResult.Txs) == 0 || len(txs) >= txsResult.TotalCount {
+			return txs, nil
+		}
+	}
+}
+
 func parseSearchingStr(searchStr string) (tmEventStrs []string, err error) {
 	var events []string
 	searchStr = strings.TrimSpace(searchStr)
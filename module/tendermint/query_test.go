@@ -131,6 +131,29 @@ func TestTendermintClient_QueryValidatorsResult(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestTendermintClient_QueryLatestHeight(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := sdk.NewClientConfig("testURL", "testChain", sdk.BroadcastBlock, "0.01okt", 200000)
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewTendermintClient(mockCli.MockBaseClient))
+
+	height := int64(1024)
+	appHash, blockIDHash := cmn.HexBytes("default app hash"), cmn.HexBytes("default block ID hash")
+
+	expectedRet := mockCli.GetRawCommitResultPointer(true, "default chainID", height, time.Now(), appHash, blockIDHash)
+	mockCli.EXPECT().Commit(nil).Return(expectedRet, nil)
+
+	latestHeight, err := mockCli.Tendermint().QueryLatestHeight()
+	require.NoError(t, err)
+	require.Equal(t, height, latestHeight)
+
+	mockCli.EXPECT().Commit(nil).Return(expectedRet, errors.New("default error"))
+	_, err = mockCli.Tendermint().QueryLatestHeight()
+	require.Error(t, err)
+}
+
 func TestTendermintClient_QueryTxResult(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -209,3 +232,96 @@ func TestTendermintClient_QueryTxsResult(t *testing.T) {
 	_, err = mockCli.Tendermint().QueryTxsResult("", 1, 30)
 	require.Error(t, err)
 }
+
+func TestTendermintClient_QueryTxsResultByQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := sdk.NewClientConfig("testURL", "testChain", sdk.BroadcastBlock, "0.01okt", 200000)
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewTendermintClient(mockCli.MockBaseClient))
+
+	txHash, tx := []byte("default tx hash"), []byte("default tx")
+	height, code := int64(1024), uint32(0)
+	log, eventType := "default log", "default event type"
+
+	query := NewTxQuery().MessageSender(addr).MessageAction("send").HeightRange(100, 1024).
+		Contains("transfer.amount", "okt").Greater("delegate.shares", 1.123456789).
+		Less("unbond.completion_time", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	expectedQueryStr := fmt.Sprintf("message.sender = '%s' AND message.action = 'send' AND tx.height >= 100 AND "+
+		"tx.height <= 1024 AND transfer.amount CONTAINS 'okt' AND delegate.shares > 1.123456789 AND "+
+		"unbond.completion_time < TIME 2020-01-01T00:00:00Z", addr)
+	queryStr, err := query.Build()
+	require.NoError(t, err)
+	require.Equal(t, expectedQueryStr, queryStr)
+	require.Equal(t, expectedQueryStr, query.String())
+
+	expectedRet := mockCli.GetRawResultTxSearchPointer(1, txHash, height, code, log, eventType, tx)
+	mockCli.EXPECT().TxSearch(expectedQueryStr, false, 1, 30).Return(expectedRet, nil)
+
+	txSearchResult, err := mockCli.Tendermint().QueryTxsResultByQuery(query, 1, 30)
+	require.NoError(t, err)
+	require.Equal(t, 1, txSearchResult.TotalCount)
+	require.Equal(t, height, txSearchResult.Txs[0].Height)
+	require.Equal(t, cmn.HexBytes(txHash), txSearchResult.Txs[0].Hash)
+
+	mockCli.EXPECT().TxSearch(expectedQueryStr, false, 1, 30).Return(expectedRet, errors.New("default error"))
+	_, err = mockCli.Tendermint().QueryTxsResultByQuery(query, 1, 30)
+	require.Error(t, err)
+
+	// the former query is reusable
+	queryStr, err = NewTxQuery().MessageSender(addr).HeightRange(0, 1024).Build()
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf("message.sender = '%s' AND tx.height <= 1024", addr), queryStr)
+
+	// bad query
+	_, err = mockCli.Tendermint().QueryTxsResultByQuery(NewTxQuery(), 1, 30)
+	require.Error(t, err)
+	_, err = mockCli.Tendermint().QueryTxsResultByQuery(NewTxQuery().HeightRange(1024, 100), 1, 30)
+	require.Error(t, err)
+	_, err = mockCli.Tendermint().QueryTxsResultByQuery(NewTxQuery().Equal("message sender", addr), 1, 30)
+	require.Error(t, err)
+	_, err = mockCli.Tendermint().QueryTxsResultByQuery(NewTxQuery().Equal("message.sender", "'"), 1, 30)
+	require.Error(t, err)
+	_, err = mockCli.Tendermint().QueryTxsResultByQuery(NewTxQuery().Equal("message.sender", []byte(addr)), 1, 30)
+	require.Error(t, err)
+	_, err = mockCli.Tendermint().QueryTxsResultByQuery(query, 0, 30)
+	require.Error(t, err)
+	_, err = mockCli.Tendermint().QueryTxsResultByQuery(query, 1, 0)
+	require.Error(t, err)
+}
+
+func TestTendermintClient_QueryAllTxsResult(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := sdk.NewClientConfig("testURL", "testChain", sdk.BroadcastBlock, "0.01okt", 200000)
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewTendermintClient(mockCli.MockBaseClient))
+
+	tx := []byte("default tx")
+	code, log, eventType := uint32(0), "default log", "default event type"
+	query := NewTxQuery().MessageSender(addr)
+	queryStr, err := query.Build()
+	require.NoError(t, err)
+
+	gomock.InOrder(
+		mockCli.EXPECT().TxSearch(queryStr, false, 1, MaxTxsPerPage).Return(mockCli.GetRawResultTxSearchPointer(3,
+			[]byte("tx hash 1"), 1, code, log, eventType, tx), nil),
+		mockCli.EXPECT().TxSearch(queryStr, false, 2, MaxTxsPerPage).Return(mockCli.GetRawResultTxSearchPointer(3,
+			[]byte("tx hash 2"), 2, code, log, eventType, tx), nil),
+		mockCli.EXPECT().TxSearch(queryStr, false, 3, MaxTxsPerPage).Return(mockCli.GetRawResultTxSearchPointer(3,
+			[]byte("tx hash 3"), 3, code, log, eventType, tx), nil),
+	)
+
+	txs, err := mockCli.Tendermint().QueryAllTxsResult(query)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(txs))
+	for i, tx := range txs {
+		require.Equal(t, int64(i+1), tx.Height)
+	}
+
+	mockCli.EXPECT().TxSearch(queryStr, false, 1, MaxTxsPerPage).Return(nil, errors.New("default error"))
+	_, err = mockCli.Tendermint().QueryAllTxsResult(query)
+	require.Error(t, err)
+}
@@ -0,0 +1,173 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// const
+const (
+	// MaxTxsPerPage is the max number of txs in a page that tendermint returns by searching
+	MaxTxsPerPage = 100
+
+	EventKeyMessageSender = "message.sender"
+	EventKeyMessageAction = "message.action"
+	EventKeyMessageModule = "message.module"
+)
+
+// operators supported in the tx searching
+const (
+	OpEqual        = "="
+	OpLess         = "<"
+	OpGreater      = ">"
+	OpLessEqual    = "<="
+	OpGreaterEqual = ">="
+	OpContains     = "CONTAINS"
+)
+
+// TxQuery - structure of the query builder for tx searching. Conditions are joined with AND
+type TxQuery struct {
+	conditions []string
+	err        error
+}
+
+// NewTxQuery creates a new instance of TxQuery without any condition
+func NewTxQuery() TxQuery {
+	return TxQuery{}
+}
+
+// Equal adds a condition that the value of the event key equals to the value
+func (tq TxQuery) Equal(key string, value interface{}) TxQuery {
+	return tq.add(key, OpEqual, value)
+}
+
+// Less adds a condition that the value of the event key is less than the value
+func (tq TxQuery) Less(key string, value interface{}) TxQuery {
+	return tq.add(key, OpLess, value)
+}
+
+// Greater adds a condition that the value of the event key is greater than the value
+func (tq TxQuery) Greater(key string, value interface{}) TxQuery {
+	return tq.add(key, OpGreater, value)
+}
+
+// LessEqual adds a condition that the value of the event key is less than or equal to the value
+func (tq TxQuery) LessEqual(key string, value interface{}) TxQuery {
+	return tq.add(key, OpLessEqual, value)
+}
+
+// GreaterEqual adds a condition that the value of the event key is greater than or equal to the value
+func (tq TxQuery) GreaterEqual(key string, value interface{}) TxQuery {
+	return tq.add(key, OpGreaterEqual, value)
+}
+
+// Contains adds a condition that the value of the event key contains the substring
+func (tq TxQuery) Contains(key, substr string) TxQuery {
+	return tq.add(key, OpContains, substr)
+}
+
+// MessageSender adds a condition on the sender of the msgs in the tx
+func (tq TxQuery) MessageSender(addrStr string) TxQuery {
+	return tq.Equal(EventKeyMessageSender, addrStr)
+}
+
+// MessageAction adds a condition on the action of the msgs in the tx
+func (tq TxQuery) MessageAction(action string) TxQuery {
+	return tq.Equal(EventKeyMessageAction, action)
+}
+
+// MessageModule adds a condition on the module that handles the msgs in the tx
+func (tq TxQuery) MessageModule(module string) TxQuery {
+	return tq.Equal(EventKeyMessageModule, module)
+}
+
+// HeightRange adds the conditions that the tx is included in the height range, inclusive. A non-positive height
+// means no limit on that side
+func (tq TxQuery) HeightRange(from, to int64) TxQuery {
+	if from > 0 && to > 0 && from > to {
+		tq.err = fmt.Errorf("failed. the end height %d is less than the start height %d", to, from)
+		return tq
+	}
+
+	if from > 0 {
+		tq = tq.GreaterEqual(tmtypes.TxHeightKey, from)
+	}
+	if to > 0 {
+		tq = tq.LessEqual(tmtypes.TxHeightKey, to)
+	}
+	return tq
+}
+
+// Build returns the query string for tendermint tx searching
+func (tq TxQuery) Build() (string, error) {
+	if tq.err != nil {
+		return "", tq.err
+	}
+
+	if len(tq.conditions) == 0 {
+		return "", errors.New("failed. empty condition to search")
+	}
+
+	return strings.Join(tq.conditions, " AND "), nil
+}
+
+// String returns the query string, or the error message if the query is invalid
+func (tq TxQuery) String() string {
+	queryStr, err := tq.Build()
+	if err != nil {
+		return err.Error()
+	}
+
+	return queryStr
+}
+
+func (tq TxQuery) add(key, op string, value interface{}) TxQuery {
+	if tq.err != nil {
+		return tq
+	}
+
+	key = strings.TrimSpace(key)
+	if len(key) == 0 || strings.ContainsAny(key, " \t\n'=<>") {
+		tq.err = fmt.Errorf("failed. invalid event key \"%s\" in the format: %s", key, EventFormat)
+		return tq
+	}
+
+	valueStr, err := formatQueryValue(value)
+	if err != nil {
+		tq.err = err
+		return tq
+	}
+
+	// copy on write to keep the former query reusable
+	conditions := make([]string, len(tq.conditions), len(tq.conditions)+1)
+	copy(conditions, tq.conditions)
+	tq.conditions = append(conditions, fmt.Sprintf("%s %s %s", key, op, valueStr))
+	return tq
+}
+
+func formatQueryValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		if strings.Contains(v, "'") {
+			return "", fmt.Errorf("failed. value %s with single quote is not supported", v)
+		}
+		return fmt.Sprintf("'%s'", v), nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprintf("%d", v), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case time.Time:
+		return fmt.Sprintf("TIME %s", v.Format(time.RFC3339)), nil
+	case fmt.Stringer:
+		return formatQueryValue(v.String())
+	default:
+		return "", fmt.Errorf("failed. unsupported type %T of the value to search", value)
+	}
+}
@@ -34,7 +34,8 @@ func NewBlock(header tmtypes.Header, data Data, evidence tmtypes.EvidenceData, l
 
 // Data - structure of the stdTxs in a block
 type Data struct {
-	Txs []sdk.StdTx `json:"txs"`
+	Txs      []sdk.StdTx    `json:"txs"`
+	TxHashes []cmn.HexBytes `json:"tx_hashes"`
 }
 
 // NewData creates a new instance of Data
@@ -44,12 +45,26 @@ func NewData(stdTxs []sdk.StdTx) Data {
 	}
 }
 
+// NewDataWithHashes creates a new instance of Data with the hashes of the txs
+func NewDataWithHashes(stdTxs []sdk.StdTx, txHashes []cmn.HexBytes) Data {
+	return Data{
+		Txs:      stdTxs,
+		TxHashes: txHashes,
+	}
+}
+
 // BlockResults - structure for ABCI results from a block
 type BlockResults struct {
 	Height  int64         `json:"height"`
 	Results ABCIResponses `json:"results"`
 }
 
+// BlockWithResults - structure of a block together with its abci results
+type BlockWithResults struct {
+	Block   Block
+	Results BlockResults
+}
+
 // ABCIResponses - structure for the responses of the various ABCI calls during block processing
 type ABCIResponses struct {
 	DeliverTx  []ResponseDeliverTx
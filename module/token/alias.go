@@ -5,10 +5,38 @@ import "github.com/okex/okchain-go-sdk/module/token/types"
 // const
 const (
 	ModuleName = types.ModuleName
+
+	DefaultMaxTransfersPerTx = types.DefaultMaxTransfersPerTx
+	PayoutStatusPending      = types.PayoutStatusPending
+	PayoutStatusSucceeded    = types.PayoutStatusSucceeded
+	PayoutStatusFailed       = types.PayoutStatusFailed
+	PayoutStatusUnconfirmed  = types.PayoutStatusUnconfirmed
 )
 
 type (
 	// nolint
-	Token             = types.Token
-	AccountTokensInfo = types.AccountTokensInfo
+	Token                = types.Token
+	AccountTokensInfo    = types.AccountTokensInfo
+	TransferEvent        = types.TransferEvent
+	MsgTransferOwnership = types.MsgTransferOwnership
+	PayoutStatus         = types.PayoutStatus
+	PayoutRecord         = types.PayoutRecord
+	PayoutConfig         = types.PayoutConfig
+	TokenSupply          = types.TokenSupply
+	TokenMeta            = types.TokenMeta
+	CoinInfoDec          = types.CoinInfoDec
+	AccountTokensInfoDec = types.AccountTokensInfoDec
+	BalanceEntry         = types.BalanceEntry
+	BalanceSheet         = types.BalanceSheet
+)
+
+var (
+	// NewPayoutRecord is the alias of the one under token/types
+	NewPayoutRecord = types.NewPayoutRecord
+	// NewPayoutConfig is the alias of the one under token/types
+	NewPayoutConfig = types.NewPayoutConfig
+	// ConfirmPayouts is the alias of the one under token/types
+	ConfirmPayouts = types.ConfirmPayouts
+	// NewBalanceSheet is the alias of the one under token/types
+	NewBalanceSheet = types.NewBalanceSheet
 )
@@ -0,0 +1,91 @@
+package token
+
+import (
+	"sync"
+	"time"
+
+	"github.com/okex/okchain-go-sdk/module/token/types"
+)
+
+const (
+	// tokenMetaTTL is the time that a cached token metadata expires in, so that the edited or removed tokens on chain
+	// are reloaded at last
+	tokenMetaTTL = 10 * time.Minute
+)
+
+// tokenMetaEntry - structure of a cached token metadata with its expiry
+type tokenMetaEntry struct {
+	meta     types.TokenMeta
+	expireAt time.Time
+}
+
+// tokenMetaCache caches the metadata of the tokens by symbol, which hardly changes once a token is issued. The whole
+// token list loaded last is kept as well to cut the pages from
+type tokenMetaCache struct {
+	mtx     sync.RWMutex
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[string]tokenMetaEntry
+	// tokens is the sorted token list loaded last, which expires at tokensExpireAt
+	tokens         []types.Token
+	tokensExpireAt time.Time
+}
+
+func newTokenMetaCache() *tokenMetaCache {
+	return &tokenMetaCache{
+		ttl:     tokenMetaTTL,
+		now:     time.Now,
+		entries: make(map[string]tokenMetaEntry),
+	}
+}
+
+func (tmc *tokenMetaCache) get(symbol string) (meta types.TokenMeta, ok bool) {
+	tmc.mtx.RLock()
+	defer tmc.mtx.RUnlock()
+	entry, ok := tmc.entries[symbol]
+	if !ok || tmc.now().After(entry.expireAt) {
+		return meta, false
+	}
+
+	return entry.meta, true
+}
+
+func (tmc *tokenMetaCache) set(tokens ...types.Token) {
+	tmc.mtx.Lock()
+	defer tmc.mtx.Unlock()
+	tmc.setLocked(tokens)
+}
+
+// reset replaces the whole cache with the sorted list of all the tokens, which prunes the ones no longer on chain
+func (tmc *tokenMetaCache) reset(tokens ...types.Token) {
+	tmc.mtx.Lock()
+	defer tmc.mtx.Unlock()
+	tmc.entries = make(map[string]tokenMetaEntry, len(tokens))
+	tmc.setLocked(tokens)
+	tmc.tokens, tmc.tokensExpireAt = tokens, tmc.now().Add(tmc.ttl)
+}
+
+// getTokens returns the token list loaded last if it hasn't expired
+func (tmc *tokenMetaCache) getTokens() ([]types.Token, bool) {
+	tmc.mtx.RLock()
+	defer tmc.mtx.RUnlock()
+	if tmc.tokens == nil || tmc.now().After(tmc.tokensExpireAt) {
+		return nil, false
+	}
+
+	return tmc.tokens, true
+}
+
+func (tmc *tokenMetaCache) setLocked(tokens []types.Token) {
+	expireAt := tmc.now().Add(tmc.ttl)
+	for _, token := range tokens {
+		tmc.entries[token.Symbol] = tokenMetaEntry{types.NewTokenMeta(token), expireAt}
+	}
+}
+
+func (tmc *tokenMetaCache) delete(symbol string) {
+	tmc.mtx.Lock()
+	defer tmc.mtx.Unlock()
+	delete(tmc.entries, symbol)
+	tmc.tokens = nil
+}
@@ -0,0 +1,120 @@
+package token
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/okex/okchain-go-sdk/module/token/types"
+	sdk "github.com/okex/okchain-go-sdk/types"
+	"github.com/okex/okchain-go-sdk/types/crypto/keys"
+	"github.com/okex/okchain-go-sdk/types/tx"
+	"github.com/okex/okchain-go-sdk/utils"
+)
+
+// GenerateUnsignedTransferOwnershipTx generates the unsigned transfer-ownership transaction offline
+func (tc tokenClient) GenerateUnsignedTransferOwnershipTx(symbol, fromAddrStr, toAddrStr, memo, outputPath string) error {
+	stdTx, err := tc.BuildUnsignedTransferOwnershipTx(symbol, fromAddrStr, toAddrStr, memo)
+	if err != nil {
+		return err
+	}
+
+	return tc.writeStdTx(stdTx, outputPath)
+}
+
+// MultiSign appends the receiver's signature to the unsigned tx file of transfer-ownership
+func (tc tokenClient) MultiSign(fromInfo keys.Info, passWd, inputPath, outputPath string) error {
+	stdTx, err := utils.GetStdTxFromFile(tc.GetCodec(), inputPath)
+	if err != nil {
+		return err
+	}
+
+	signedTx, err := tc.SignTransferOwnershipTx(fromInfo, passWd, stdTx)
+	if err != nil {
+		return err
+	}
+
+	return tc.writeStdTx(signedTx, outputPath)
+}
+
+// BuildUnsignedTransferOwnershipTx builds the unsigned transfer-ownership transaction in memory
+func (tc tokenClient) BuildUnsignedTransferOwnershipTx(symbol, fromAddrStr, toAddrStr, memo string) (stdTx sdk.StdTx,
+	err error) {
+	if len(symbol) == 0 {
+		return stdTx, errors.New("failed. empty token symbol input")
+	}
+
+	fromAddr, err := sdk.AccAddressFromBech32(fromAddrStr)
+	if err != nil {
+		return stdTx, fmt.Errorf("failed. parse Address [%s] error: %s", fromAddrStr, err)
+	}
+
+	toAddr, err := sdk.AccAddressFromBech32(toAddrStr)
+	if err != nil {
+		return stdTx, fmt.Errorf("failed. parse Address [%s] error: %s", toAddrStr, err)
+	}
+
+	if fromAddr.Equals(toAddr) {
+		return stdTx, errors.New("failed. the receiver is already the owner")
+	}
+
+	msg := types.NewMsgTransferOwnership(fromAddr, toAddr, symbol)
+	return tc.BuildUnsignedStdTxOffline([]sdk.Msg{msg}, memo), nil
+}
+
+// SignTransferOwnershipTx appends the receiver's signature to the unsigned transfer-ownership transaction in memory
+func (tc tokenClient) SignTransferOwnershipTx(fromInfo keys.Info, passWd string, stdTx sdk.StdTx) (signedTx sdk.StdTx,
+	err error) {
+	if fromInfo == nil {
+		return signedTx, errors.New("failed. input invalid keys info")
+	}
+
+	msg, err := getMsgTransferOwnership(stdTx)
+	if err != nil {
+		return
+	}
+
+	if !msg.ToAddress.Equals(fromInfo.GetAddress()) {
+		return signedTx, fmt.Errorf("failed. only the receiver %s is allowed to sign", msg.ToAddress)
+	}
+
+	// sign the msg without any receiver's signature
+	msg.ToSignature = sdk.StdSignature{}
+	signature, _, err := tx.Kb.Sign(fromInfo.GetName(), passWd, msg.GetSignBytes())
+	if err != nil {
+		return signedTx, fmt.Errorf("failed. sign error: %s", err.Error())
+	}
+
+	msg.ToSignature = sdk.NewStdSignature(fromInfo.GetPubKey(), signature)
+	return tc.BuildUnsignedStdTxOffline([]sdk.Msg{msg}, stdTx.Memo), nil
+}
+
+func (tc tokenClient) writeStdTx(stdTx sdk.StdTx, outputPath string) error {
+	jsonBytes, err := tc.GetCodec().MarshalJSON(stdTx)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(outputPath, jsonBytes, 0644)
+}
+
+func getMsgTransferOwnership(stdTx sdk.StdTx) (msg types.MsgTransferOwnership, err error) {
+	rawMsg, err := utils.GetTransferOwnershipMsg(stdTx)
+	if err != nil {
+		return
+	}
+
+	msg, ok := rawMsg.(types.MsgTransferOwnership)
+	if !ok {
+		return msg, errors.New("failed. invalid msg type")
+	}
+
+	return
+}
+
+// checkToSignature verifies that the receiver's signature in the msg is signed by the receiver over the msg itself
+func checkToSignature(msg types.MsgTransferOwnership) error {
+	toSig := msg.ToSignature
+	msg.ToSignature = sdk.StdSignature{}
+	return utils.CheckToSignature(msg.ToAddress, toSig, msg.GetSignBytes())
+}
@@ -0,0 +1,140 @@
+package token
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/okex/okchain-go-sdk/mocks"
+	"github.com/okex/okchain-go-sdk/module"
+	"github.com/okex/okchain-go-sdk/module/token/types"
+	sdk "github.com/okex/okchain-go-sdk/types"
+	"github.com/okex/okchain-go-sdk/utils"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	recName     = "bob"
+	recMnemonic = "pepper basket run install fury scheme journey worry tumble toddler swap change"
+)
+
+func TestTokenClient_TransferOwnershipOffline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := sdk.NewClientConfig("testURL", "testChain", sdk.BroadcastBlock, "0.01okt", 200000)
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewTokenClient(mockCli.MockBaseClient))
+	cdc := mockCli.GetCodec()
+	tokenClient := NewTokenClient(module.NewBaseClient(cdc, &config))
+
+	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
+	require.NoError(t, err)
+	recInfo, _, err := utils.CreateAccountWithMnemo(recMnemonic, recName, passWd)
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "token-offline")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	unsignedPath, signedPath := filepath.Join(dir, "unsignedTx.json"), filepath.Join(dir, "signedTx.json")
+
+	// generate unsigned transfer ownership tx
+	err = tokenClient.GenerateUnsignedTransferOwnershipTx(tokenSymbol, addr, recAddr, memo, unsignedPath)
+	require.NoError(t, err)
+
+	err = tokenClient.GenerateUnsignedTransferOwnershipTx("", addr, recAddr, memo, unsignedPath)
+	require.Error(t, err)
+
+	err = tokenClient.GenerateUnsignedTransferOwnershipTx(tokenSymbol, addr[1:], recAddr, memo, unsignedPath)
+	require.Error(t, err)
+
+	err = tokenClient.GenerateUnsignedTransferOwnershipTx(tokenSymbol, addr, recAddr[1:], memo, unsignedPath)
+	require.Error(t, err)
+
+	err = tokenClient.GenerateUnsignedTransferOwnershipTx(tokenSymbol, addr, addr, memo, unsignedPath)
+	require.Error(t, err)
+
+	// only the receiver is allowed to sign
+	err = tokenClient.MultiSign(fromInfo, passWd, unsignedPath, signedPath)
+	require.Error(t, err)
+
+	err = tokenClient.MultiSign(recInfo, passWd[1:], unsignedPath, signedPath)
+	require.Error(t, err)
+
+	err = tokenClient.MultiSign(recInfo, passWd, unsignedPath[1:], signedPath)
+	require.Error(t, err)
+
+	err = tokenClient.MultiSign(recInfo, passWd, unsignedPath, signedPath)
+	require.NoError(t, err)
+
+	// read back to check
+	stdTx, err := utils.GetStdTxFromFile(cdc, signedPath)
+	require.NoError(t, err)
+	require.Equal(t, memo, stdTx.Memo)
+	require.Equal(t, 1, len(stdTx.Msgs))
+	msg, ok := stdTx.Msgs[0].(types.MsgTransferOwnership)
+	require.True(t, ok)
+	require.Equal(t, tokenSymbol, msg.Symbol)
+	require.NoError(t, checkToSignature(msg))
+
+	// broadcast by the owner
+	mockCli.EXPECT().GetCodec().Return(cdc).Times(3)
+	mockCli.EXPECT().BuildAndBroadcast(fromInfo.GetName(), passWd, memo, []sdk.Msg{msg}, uint64(1),
+		uint64(2)).Return(mocks.DefaultMockSuccessTxResponse(), nil)
+	res, err := mockCli.Token().TransferOwnership(fromInfo, passWd, signedPath, 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), res.Code)
+
+	// the unsigned tx is rejected
+	_, err = mockCli.Token().TransferOwnership(fromInfo, passWd, unsignedPath, 1, 2)
+	require.Error(t, err)
+
+	// only the owner is allowed to broadcast
+	_, err = mockCli.Token().TransferOwnership(recInfo, passWd, signedPath, 1, 2)
+	require.Error(t, err)
+
+	_, err = mockCli.Token().TransferOwnership(fromInfo, "", signedPath, 1, 2)
+	require.Error(t, err)
+}
+
+func TestTokenClient_TransferOwnershipInMemory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := sdk.NewClientConfig("testURL", "testChain", sdk.BroadcastBlock, "0.01okt", 200000)
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewTokenClient(mockCli.MockBaseClient))
+	tokenClient := NewTokenClient(module.NewBaseClient(mockCli.GetCodec(), &config))
+
+	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
+	require.NoError(t, err)
+	recInfo, _, err := utils.CreateAccountWithMnemo(recMnemonic, recName, passWd)
+	require.NoError(t, err)
+
+	unsignedTx, err := tokenClient.BuildUnsignedTransferOwnershipTx(tokenSymbol, addr, recAddr, memo)
+	require.NoError(t, err)
+
+	signedTx, err := tokenClient.SignTransferOwnershipTx(recInfo, passWd, unsignedTx)
+	require.NoError(t, err)
+
+	_, err = tokenClient.SignTransferOwnershipTx(nil, passWd, unsignedTx)
+	require.Error(t, err)
+
+	_, err = tokenClient.SignTransferOwnershipTx(recInfo, passWd, sdk.StdTx{})
+	require.Error(t, err)
+
+	mockCli.EXPECT().BuildAndBroadcast(fromInfo.GetName(), passWd, memo, signedTx.Msgs, uint64(1),
+		uint64(2)).Return(mocks.DefaultMockSuccessTxResponse(), nil)
+	res, err := mockCli.Token().BroadcastTransferOwnershipTx(fromInfo, passWd, signedTx, 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), res.Code)
+
+	// tampered symbol makes the receiver's signature invalid
+	msg := signedTx.Msgs[0].(types.MsgTransferOwnership)
+	msg.Symbol = "eth-000"
+	signedTx.Msgs = []sdk.Msg{msg}
+	_, err = mockCli.Token().BroadcastTransferOwnershipTx(fromInfo, passWd, signedTx, 1, 2)
+	require.Error(t, err)
+}
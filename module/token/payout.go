@@ -0,0 +1,142 @@
+package token
+
+import (
+	"fmt"
+
+	"github.com/okex/okchain-go-sdk/module/token/types"
+	sdk "github.com/okex/okchain-go-sdk/types"
+	"github.com/okex/okchain-go-sdk/types/crypto/keys"
+	"github.com/okex/okchain-go-sdk/types/params"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// payoutBatch - a group of payout records sent in a single multi-send tx
+type payoutBatch struct {
+	indexes   []int
+	transfers []types.TransferUnit
+}
+
+// BatchPayout splits the unpaid records into multi-send txs within the limits of config and broadcasts them with
+// consecutive sequence numbers starting from seqNum. It stops at the first failed tx and returns a copy of the records
+// with the results, which can be passed in again with the latest sequence number to resume the payout
+// NOTE: the records are failed only if their tx is rejected or not broadcasted. The ones in a tx broadcasted with an
+// unknown result, such as a broadcast error or the sync/async broadcast mode, are unconfirmed with the tx hash. They are
+// skipped in resuming until resolved by types.ConfirmPayouts after looking up the tx hash
+func (tc tokenClient) BatchPayout(fromInfo keys.Info, passWd string, records []types.PayoutRecord,
+	config types.PayoutConfig, memo string, accNum, seqNum uint64) (results []types.PayoutRecord, err error) {
+	if err = params.CheckBatchPayoutParams(fromInfo, passWd, records, config); err != nil {
+		return
+	}
+
+	batches, err := splitPayouts(fromInfo.GetAddress(), records, config)
+	if err != nil {
+		return
+	}
+
+	results = make([]types.PayoutRecord, len(records))
+	copy(results, records)
+	for i, batch := range batches {
+		msg := types.NewMsgMultiSend(fromInfo.GetAddress(), batch.transfers)
+		txHash, resp, err := tc.broadcastPayout(fromInfo.GetName(), passWd, memo, msg, accNum, seqNum)
+		status := types.PayoutStatusSucceeded
+		switch {
+		case err != nil && len(txHash) == 0:
+			status = types.PayoutStatusFailed
+		case err != nil:
+			// the tx may have reached the mempool
+			status = types.PayoutStatusUnconfirmed
+		case resp.Code != 0:
+			status = types.PayoutStatusFailed
+			err = fmt.Errorf("failed. tx %s is rejected with code %d: %s", txHash, resp.Code, resp.RawLog)
+		case resp.Height == 0:
+			// only passed CheckTx in the sync/async broadcast mode
+			status = types.PayoutStatusUnconfirmed
+		}
+
+		for _, index := range batch.indexes {
+			results[index].Status, results[index].TxHash, results[index].Error = status, txHash, ""
+			if err != nil {
+				results[index].Error = err.Error()
+			}
+		}
+
+		if err != nil {
+			return results, fmt.Errorf("failed. batch %d of %d in payout error: %s", i+1, len(batches), err)
+		}
+		seqNum++
+	}
+
+	return results, nil
+}
+
+// broadcastPayout signs and broadcasts the multi-send tx. The tx hash is computed before the broadcast, so an empty one
+// with the error means the tx isn't broadcasted at all
+func (tc tokenClient) broadcastPayout(fromName, passWd, memo string, msg sdk.Msg, accNum, seqNum uint64) (
+	txHash string, resp sdk.TxResponse, err error) {
+	stdTx, err := tc.BuildStdTx(fromName, passWd, memo, []sdk.Msg{msg}, accNum, seqNum)
+	if err != nil {
+		return txHash, resp, fmt.Errorf("failed. build stdTx error: %s", err)
+	}
+
+	txBytes, err := tc.GetCodec().MarshalBinaryLengthPrefixed(stdTx)
+	if err != nil {
+		return txHash, resp, fmt.Errorf("failed. encoded stdTx error: %s", err)
+	}
+
+	txHash = cmn.HexBytes(tmtypes.Tx(txBytes).Hash()).String()
+	resp, err = tc.Broadcast(txBytes, tc.GetConfig().BroadcastMode)
+	return
+}
+
+// splitPayouts groups the unpaid records into batches within the limits of config
+func splitPayouts(fromAddr sdk.AccAddress, records []types.PayoutRecord, config types.PayoutConfig) (
+	batches []payoutBatch, err error) {
+	maxTransfers := config.MaxTransfersPerTx
+	if maxTransfers == 0 {
+		maxTransfers = types.DefaultMaxTransfersPerTx
+	}
+
+	var batch payoutBatch
+	for i, record := range records {
+		if record.IsDone() || record.IsUnconfirmed() {
+			continue
+		}
+
+		transfer, err := record.ToTransferUnit()
+		if err != nil {
+			return nil, fmt.Errorf("failed. payout %d: %s", i, err)
+		}
+
+		if len(batch.transfers) == maxTransfers ||
+			(len(batch.transfers) != 0 && !isMsgSizeFit(fromAddr, batch.transfers, transfer, config)) {
+			batches = append(batches, batch)
+			batch = payoutBatch{}
+		}
+
+		if len(batch.transfers) == 0 && !isMsgSizeFit(fromAddr, nil, transfer, config) {
+			return nil, fmt.Errorf("failed. payout %d exceeds the max msg bytes %d alone", i, config.MaxMsgBytes)
+		}
+
+		batch.indexes = append(batch.indexes, i)
+		batch.transfers = append(batch.transfers, transfer)
+	}
+
+	if len(batch.transfers) != 0 {
+		batches = append(batches, batch)
+	}
+
+	return
+}
+
+func isMsgSizeFit(fromAddr sdk.AccAddress, transfers []types.TransferUnit, transfer types.TransferUnit,
+	config types.PayoutConfig) bool {
+	if config.MaxMsgBytes == 0 {
+		return true
+	}
+
+	candidate := make([]types.TransferUnit, len(transfers), len(transfers)+1)
+	copy(candidate, transfers)
+	candidate = append(candidate, transfer)
+	return len(types.NewMsgMultiSend(fromAddr, candidate).GetSignBytes()) <= config.MaxMsgBytes
+}
@@ -0,0 +1,171 @@
+package token
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/okex/okchain-go-sdk/mocks"
+	"github.com/okex/okchain-go-sdk/module/token/types"
+	sdk "github.com/okex/okchain-go-sdk/types"
+	"github.com/okex/okchain-go-sdk/utils"
+	"github.com/stretchr/testify/require"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+func TestTokenClient_BatchPayout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := sdk.NewClientConfig("testURL", "testChain", sdk.BroadcastBlock, "0.01okt", 200000)
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewTokenClient(mockCli.MockBaseClient))
+
+	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
+	require.NoError(t, err)
+
+	records := make([]types.PayoutRecord, 5)
+	for i := range records {
+		records[i] = types.NewPayoutRecord(recAddr, "1.024okt")
+	}
+
+	transfers := make([]types.TransferUnit, len(records))
+	for i, record := range records {
+		transfers[i], err = record.ToTransferUnit()
+		require.NoError(t, err)
+	}
+
+	expectedCdc := mockCli.GetCodec()
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).AnyTimes()
+	mockCli.EXPECT().GetConfig().Return(config).AnyTimes()
+	// expectPayoutTx expects a multi-send tx signed and broadcasted, and returns its hash
+	expectPayoutTx := func(transfers []types.TransferUnit, seqNum uint64, resp sdk.TxResponse, err error) string {
+		msgs := []sdk.Msg{types.NewMsgMultiSend(fromInfo.GetAddress(), transfers)}
+		// the signature differs with the sequence like the real one
+		stdTx := sdk.NewStdTx(msgs, sdk.NewStdFee(config.Gas, config.Fees),
+			[]sdk.StdSignature{{Signature: []byte{byte(seqNum)}}}, memo)
+		txBytes := expectedCdc.MustMarshalBinaryLengthPrefixed(stdTx)
+		mockCli.EXPECT().BuildStdTx(fromInfo.GetName(), passWd, memo, msgs, uint64(1), seqNum).Return(stdTx, nil)
+		mockCli.EXPECT().Broadcast(txBytes, sdk.BroadcastBlock).Return(resp, err)
+		return cmn.HexBytes(tmtypes.Tx(txBytes).Hash()).String()
+	}
+
+	// split into 3 txs and the 2nd one fails
+	hash1 := expectPayoutTx(transfers[:2], 2, mocks.DefaultMockSuccessTxResponse(), nil)
+	hash2 := expectPayoutTx(transfers[2:4], 3, sdk.TxResponse{Code: 5, RawLog: "insufficient coins"}, nil)
+	results, err := mockCli.Token().BatchPayout(fromInfo, passWd, records, types.NewPayoutConfig(2, 0), memo, 1, 2)
+	require.Error(t, err)
+	require.Equal(t, 5, len(results))
+	require.Equal(t, types.PayoutStatusSucceeded, results[0].Status)
+	require.Equal(t, types.PayoutStatusSucceeded, results[1].Status)
+	require.Equal(t, hash1, results[1].TxHash)
+	require.Equal(t, types.PayoutStatusFailed, results[2].Status)
+	require.Equal(t, hash2, results[3].TxHash)
+	require.Contains(t, results[3].Error, "insufficient coins")
+	require.Equal(t, types.PayoutStatusPending, results[4].Status)
+	// input isn't modified
+	require.Equal(t, types.PayoutStatusPending, records[0].Status)
+
+	// resume with the unpaid records only
+	expectPayoutTx(transfers[2:], 3, mocks.DefaultMockSuccessTxResponse(), nil)
+	results, err = mockCli.Token().BatchPayout(fromInfo, passWd, results, types.NewPayoutConfig(0, 0), memo, 1, 3)
+	require.NoError(t, err)
+	for _, result := range results {
+		require.True(t, result.IsDone())
+		require.Empty(t, result.Error)
+	}
+
+	// nothing to pay
+	results, err = mockCli.Token().BatchPayout(fromInfo, passWd, results, types.NewPayoutConfig(0, 0), memo, 1, 4)
+	require.NoError(t, err)
+	require.Equal(t, 5, len(results))
+
+	// the records in the tx with a broadcast error are unconfirmed with the tx hash
+	hash1 = expectPayoutTx(transfers, 2, sdk.TxResponse{}, errors.New("default error"))
+	results, err = mockCli.Token().BatchPayout(fromInfo, passWd, records, types.NewPayoutConfig(0, 0), memo, 1, 2)
+	require.Error(t, err)
+	for _, result := range results {
+		require.True(t, result.IsUnconfirmed())
+		require.Equal(t, hash1, result.TxHash)
+		require.Equal(t, "default error", result.Error)
+	}
+
+	// the unconfirmed records are skipped in resuming until confirmed
+	results, err = mockCli.Token().BatchPayout(fromInfo, passWd, results, types.NewPayoutConfig(0, 0), memo, 1, 2)
+	require.NoError(t, err)
+	types.ConfirmPayouts(results, hash1, false)
+	for _, result := range results {
+		require.Equal(t, types.PayoutStatusPending, result.Status)
+	}
+
+	// the records in the tx only passed CheckTx are unconfirmed
+	hash1 = expectPayoutTx(transfers[:2], 2, sdk.TxResponse{TxHash: "sync hash"}, nil)
+	hash2 = expectPayoutTx(transfers[2:4], 3, sdk.TxResponse{TxHash: "sync hash"}, nil)
+	expectPayoutTx(transfers[4:], 4, sdk.TxResponse{}, errors.New("default error"))
+	results, err = mockCli.Token().BatchPayout(fromInfo, passWd, results, types.NewPayoutConfig(2, 0), memo, 1, 2)
+	require.Error(t, err)
+	require.Equal(t, types.PayoutStatusUnconfirmed, results[0].Status)
+	require.Equal(t, hash1, results[1].TxHash)
+	require.Empty(t, results[1].Error)
+	require.Equal(t, hash2, results[2].TxHash)
+	types.ConfirmPayouts(results, hash1, true)
+	require.True(t, results[0].IsDone())
+	require.True(t, results[1].IsDone())
+	require.True(t, results[2].IsUnconfirmed())
+
+	// the records are failed without the tx broadcasted
+	mockCli.EXPECT().BuildStdTx(fromInfo.GetName(), passWd, memo, gomock.Any(), uint64(1), uint64(2)).
+		Return(sdk.StdTx{}, errors.New("default error"))
+	results, err = mockCli.Token().BatchPayout(fromInfo, passWd, records, types.NewPayoutConfig(0, 0), memo, 1, 2)
+	require.Error(t, err)
+	for _, result := range results {
+		require.Equal(t, types.PayoutStatusFailed, result.Status)
+		require.Empty(t, result.TxHash)
+	}
+
+	// bad params
+	_, err = mockCli.Token().BatchPayout(fromInfo, "", records, types.NewPayoutConfig(0, 0), memo, 1, 2)
+	require.Error(t, err)
+	_, err = mockCli.Token().BatchPayout(fromInfo, passWd, nil, types.NewPayoutConfig(0, 0), memo, 1, 2)
+	require.Error(t, err)
+	_, err = mockCli.Token().BatchPayout(fromInfo, passWd, records, types.NewPayoutConfig(-1, 0), memo, 1, 2)
+	require.Error(t, err)
+	_, err = mockCli.Token().BatchPayout(fromInfo, passWd, []types.PayoutRecord{types.NewPayoutRecord(recAddr, "okt")},
+		types.NewPayoutConfig(0, 0), memo, 1, 2)
+	require.Error(t, err)
+}
+
+func TestSplitPayouts(t *testing.T) {
+	fromAddr, err := sdk.AccAddressFromBech32(addr)
+	require.NoError(t, err)
+
+	records := make([]types.PayoutRecord, 10)
+	for i := range records {
+		records[i] = types.NewPayoutRecord(recAddr, "1okt")
+	}
+	records[3].Status = types.PayoutStatusSucceeded
+
+	batches, err := splitPayouts(fromAddr, records, types.NewPayoutConfig(4, 0))
+	require.NoError(t, err)
+	require.Equal(t, 3, len(batches))
+	require.Equal(t, []int{0, 1, 2, 4}, batches[0].indexes)
+	require.Equal(t, []int{5, 6, 7, 8}, batches[1].indexes)
+	require.Equal(t, []int{9}, batches[2].indexes)
+
+	// limited by the msg bytes
+	transfer, err := records[0].ToTransferUnit()
+	require.NoError(t, err)
+	maxMsgBytes := len(types.NewMsgMultiSend(fromAddr, []types.TransferUnit{transfer, transfer, transfer}).GetSignBytes())
+	batches, err = splitPayouts(fromAddr, records, types.NewPayoutConfig(0, maxMsgBytes))
+	require.NoError(t, err)
+	require.Equal(t, 3, len(batches))
+	for _, batch := range batches {
+		require.True(t, len(batch.transfers) <= 3)
+		require.True(t, len(types.NewMsgMultiSend(fromAddr, batch.transfers).GetSignBytes()) <= maxMsgBytes)
+	}
+
+	// a single payout is too large
+	_, err = splitPayouts(fromAddr, records, types.NewPayoutConfig(0, 10))
+	require.Error(t, err)
+}
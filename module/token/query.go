@@ -2,7 +2,10 @@ package token
 
 import (
 	"fmt"
+	"sort"
+
 	"github.com/okex/okchain-go-sdk/module/token/types"
+	sdk "github.com/okex/okchain-go-sdk/types"
 	"github.com/okex/okchain-go-sdk/types/params"
 	"github.com/okex/okchain-go-sdk/utils"
 )
@@ -55,6 +58,28 @@ func (tc tokenClient) QueryAccountTokensInfo(addrStr string) (accTokensInfo type
 	return
 }
 
+// QueryAccountTokensInfoDec gets all the available tokens info of an account with the amounts in sdk.Dec
+func (tc tokenClient) QueryAccountTokensInfoDec(addrStr string) (accTokensInfoDec types.AccountTokensInfoDec,
+	err error) {
+	accTokensInfo, err := tc.QueryAccountTokensInfo(addrStr)
+	if err != nil {
+		return
+	}
+
+	return accTokensInfo.ToDec()
+}
+
+// QueryAccountTokenInfoDec gets a specific available token info of an account with the amounts in sdk.Dec
+func (tc tokenClient) QueryAccountTokenInfoDec(addrStr, symbol string) (accTokensInfoDec types.AccountTokensInfoDec,
+	err error) {
+	accTokensInfo, err := tc.QueryAccountTokenInfo(addrStr, symbol)
+	if err != nil {
+		return
+	}
+
+	return accTokensInfo.ToDec()
+}
+
 // QueryTokenInfo gets token info with a specific symbol or the owner address
 func (tc tokenClient) QueryTokenInfo(ownerAddr, symbol string) (tokens []types.Token, err error) {
 	if err = params.CheckQueryTokenInfoParams(ownerAddr, symbol); err != nil {
@@ -68,17 +93,139 @@ func (tc tokenClient) QueryTokenInfo(ownerAddr, symbol string) (tokens []types.T
 		}
 
 		var token types.Token
-		tc.GetCodec().MustUnmarshalJSON(res, &token)
-		tokens = append(tokens, token)
-		return tokens, err
+		if err = tc.GetCodec().UnmarshalJSON(res, &token); err != nil {
+			return tokens, utils.ErrUnmarshalJSON(err.Error())
+		}
+
+		tc.metaCache.set(token)
+		return []types.Token{token}, nil
 	}
 
-	res, err := tc.Query(fmt.Sprintf("custom/%s/tokens/%s", types.ModuleName, ownerAddr), nil)
+	res, err := tc.Query(fmt.Sprintf("%s/%s", types.TokensPath, ownerAddr), nil)
 	if err != nil {
 		return tokens, fmt.Errorf("failed. %s doesn't own any tokens: %s", ownerAddr, err.Error())
 	}
 
-	tc.GetCodec().MustUnmarshalJSON(res, &tokens)
+	if err = tc.GetCodec().UnmarshalJSON(res, &tokens); err != nil {
+		return tokens, utils.ErrUnmarshalJSON(err.Error())
+	}
+
+	tc.metaCache.set(tokens...)
 	return
+}
+
+// QueryAllTokens gets the info of all the tokens on chain, which reloads the whole token metadata cache as well
+func (tc tokenClient) QueryAllTokens() (tokens []types.Token, err error) {
+	res, err := tc.Query(types.TokensPath, nil)
+	if err != nil {
+		return tokens, utils.ErrClientQuery(err.Error())
+	}
+
+	if err = tc.GetCodec().UnmarshalJSON(res, &tokens); err != nil {
+		return tokens, utils.ErrUnmarshalJSON(err.Error())
+	}
+
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].Symbol < tokens[j].Symbol
+	})
+	tc.metaCache.reset(tokens...)
+	return
+}
+
+// QueryTokens gets the info of the tokens on chain in a page ordered by symbol
+// NOTE: the token querier on chain serves no paging, so the pages are cut from the token list cached by QueryAllTokens.
+// The list is queried only once it expires, which makes a walk through the pages cost one query
+func (tc tokenClient) QueryTokens(page, perPage int) (tokens []types.Token, err error) {
+	if err = params.CheckQueryTokensParams(page, perPage); err != nil {
+		return
+	}
+
+	allTokens, ok := tc.metaCache.getTokens()
+	if !ok {
+		if allTokens, err = tc.QueryAllTokens(); err != nil {
+			return
+		}
+	}
+
+	start := (page - 1) * perPage
+	if start >= len(allTokens) {
+		return []types.Token{}, nil
+	}
+
+	end := start + perPage
+	if end > len(allTokens) {
+		end = len(allTokens)
+	}
+
+	return allTokens[start:end], nil
+}
+
+// QueryTokenSupply gets the total supply and the circulating supply of a token. The circulating supply excludes the
+// holdings of the token owner, including the available, frozen and locked ones
+func (tc tokenClient) QueryTokenSupply(symbol string) (supply types.TokenSupply, err error) {
+	tokens, err := tc.QueryTokenInfo("", symbol)
+	if err != nil {
+		return
+	}
+
+	token := tokens[0]
+	if token.Symbol != symbol {
+		return supply, fmt.Errorf("failed. token %s doesn't exist", symbol)
+	}
+
+	accTokensInfo, err := tc.QueryAccountTokenInfo(token.Owner.String(), symbol)
+	if err != nil {
+		return
+	}
+
+	ownerHoldings := sdk.ZeroDec()
+	for _, coinInfo := range accTokensInfo.Currencies {
+		if coinInfo.Symbol != symbol {
+			continue
+		}
+
+		coinInfoDec, err := coinInfo.ToDec()
+		if err != nil {
+			return supply, err
+		}
+		ownerHoldings = ownerHoldings.Add(coinInfoDec.Total)
+	}
+
+	circulatingSupply := token.TotalSupply.Sub(ownerHoldings)
+	if circulatingSupply.IsNegative() {
+		circulatingSupply = sdk.ZeroDec()
+	}
+
+	return types.TokenSupply{
+		Symbol:              symbol,
+		OriginalTotalSupply: token.OriginalTotalSupply,
+		TotalSupply:         token.TotalSupply,
+		OwnerHoldings:       ownerHoldings,
+		CirculatingSupply:   circulatingSupply,
+	}, nil
+}
+
+// QueryTokenMeta gets the metadata of a token by its symbol, which is the denom of DecCoin, from the cache first. The
+// cached metadata expires in 10 minutes
+func (tc tokenClient) QueryTokenMeta(symbol string) (meta types.TokenMeta, err error) {
+	if meta, ok := tc.metaCache.get(symbol); ok {
+		return meta, nil
+	}
+
+	tokens, err := tc.QueryTokenInfo("", symbol)
+	if err != nil {
+		return
+	}
+
+	if tokens[0].Symbol != symbol {
+		return meta, fmt.Errorf("failed. token %s doesn't exist", symbol)
+	}
+
+	return types.NewTokenMeta(tokens[0]), nil
+}
 
+// RefreshTokenMetas reloads the metadata of all the tokens into the cache and prunes the ones no longer on chain
+func (tc tokenClient) RefreshTokenMetas() error {
+	_, err := tc.QueryAllTokens()
+	return err
 }
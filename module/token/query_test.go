@@ -13,6 +13,7 @@ import (
 	cmn "github.com/tendermint/tendermint/libs/common"
 
 	"testing"
+	"time"
 )
 
 const (
@@ -168,8 +169,252 @@ func TestTokenClient_QueryTokenInfo(t *testing.T) {
 	_, err = mockCli.Token().QueryTokenInfo("", "")
 	require.Error(t, err)
 
+	mockCli.EXPECT().GetCodec().Return(expectedCdc)
+	mockCli.EXPECT().Query(fmt.Sprintf("custom/%s/tokens/%s", types.ModuleName, addr), nil).Return(expectedRet[1:], nil)
+	_, err = mockCli.Token().QueryTokenInfo(addr, "")
+	require.Error(t, err)
+
 	mockCli.EXPECT().Query(fmt.Sprintf("custom/%s/tokens/%s", types.ModuleName, addr), nil).
 		Return(expectedRet, errors.New("default error"))
 	_, err = mockCli.Token().QueryTokenInfo(addr, "")
 	require.Error(t, err)
 }
+
+func TestTokenClient_QueryTokens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := sdk.NewClientConfig("testURL", "testChain", sdk.BroadcastBlock, "0.01okt", 200000)
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewTokenClient(mockCli.MockBaseClient))
+
+	expectedCdc := mockCli.GetCodec()
+	symbols := []string{"okt", "eth-000", tokenSymbol}
+	tokens := make([]types.Token, len(symbols))
+	for i, symbol := range symbols {
+		tokens[i] = types.Token{Symbol: symbol, WholeName: "whole name " + symbol, TotalSupply: sdk.OneDec(),
+			OriginalTotalSupply: sdk.OneDec()}
+	}
+	expectedRet := expectedCdc.MustMarshalJSON(tokens)
+
+	// the pages are cut from the token list queried once
+	mockCli.EXPECT().GetCodec().Return(expectedCdc)
+	mockCli.EXPECT().Query(types.TokensPath, nil).Return(expectedRet, nil)
+
+	allTokens, err := mockCli.Token().QueryAllTokens()
+	require.NoError(t, err)
+	require.Equal(t, 3, len(allTokens))
+	// ordered by symbol
+	require.Equal(t, tokenSymbol, allTokens[0].Symbol)
+	require.Equal(t, "eth-000", allTokens[1].Symbol)
+	require.Equal(t, "okt", allTokens[2].Symbol)
+
+	pageTokens, err := mockCli.Token().QueryTokens(1, 2)
+	require.NoError(t, err)
+	require.Equal(t, allTokens[:2], pageTokens)
+
+	pageTokens, err = mockCli.Token().QueryTokens(2, 2)
+	require.NoError(t, err)
+	require.Equal(t, allTokens[2:], pageTokens)
+
+	pageTokens, err = mockCli.Token().QueryTokens(3, 2)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(pageTokens))
+
+	_, err = mockCli.Token().QueryTokens(0, 2)
+	require.Error(t, err)
+
+	_, err = mockCli.Token().QueryTokens(1, 0)
+	require.Error(t, err)
+
+	mockCli.EXPECT().Query(types.TokensPath, nil).Return(expectedRet, errors.New("default error"))
+	_, err = mockCli.Token().QueryAllTokens()
+	require.Error(t, err)
+
+	mockCli.EXPECT().GetCodec().Return(expectedCdc)
+	mockCli.EXPECT().Query(types.TokensPath, nil).Return(expectedRet[1:], nil)
+	_, err = mockCli.Token().QueryAllTokens()
+	require.Error(t, err)
+}
+
+func TestTokenClient_QueryTokenSupply(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := sdk.NewClientConfig("testURL", "testChain", sdk.BroadcastBlock, "0.01okt", 200000)
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewTokenClient(mockCli.MockBaseClient))
+
+	originalTotalSupply, err := sdk.NewDecFromStr("10000")
+	require.NoError(t, err)
+	totalSupply, err := sdk.NewDecFromStr("20000")
+	require.NoError(t, err)
+	ownerAddr, err := sdk.AccAddressFromBech32(addr)
+	require.NoError(t, err)
+
+	expectedCdc := mockCli.GetCodec()
+	tokenInfoBytes := mockCli.BuildTokenInfoBytes("default description", tokenSymbol, "btc",
+		"default whole name", originalTotalSupply, totalSupply, ownerAddr, true, false)
+	accTokensInfoBytes := mockCli.BuildAccountTokensInfoBytes(addr, tokenSymbol, "1024.5", "2048", "")
+	queryBytes := expectedCdc.MustMarshalJSON(params.NewQueryAccTokenParams(tokenSymbol, "partial"))
+
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).Times(3)
+	mockCli.EXPECT().Query(fmt.Sprintf("custom/%s/info/%s", types.ModuleName, tokenSymbol), nil).
+		Return(tokenInfoBytes, nil)
+	mockCli.EXPECT().Query(fmt.Sprintf("%s/%s", types.AccountTokensInfoPath, addr), cmn.HexBytes(queryBytes)).
+		Return(accTokensInfoBytes, nil)
+
+	supply, err := mockCli.Token().QueryTokenSupply(tokenSymbol)
+	require.NoError(t, err)
+	require.Equal(t, tokenSymbol, supply.Symbol)
+	require.Equal(t, originalTotalSupply, supply.OriginalTotalSupply)
+	require.Equal(t, totalSupply, supply.TotalSupply)
+	require.Equal(t, "3072.50000000", supply.OwnerHoldings.String())
+	require.Equal(t, "16927.50000000", supply.CirculatingSupply.String())
+
+	// invalid amount
+	accTokensInfoBytes = mockCli.BuildAccountTokensInfoBytes(addr, tokenSymbol, "1024,5", "2048", "")
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).Times(3)
+	mockCli.EXPECT().Query(fmt.Sprintf("custom/%s/info/%s", types.ModuleName, tokenSymbol), nil).
+		Return(tokenInfoBytes, nil)
+	mockCli.EXPECT().Query(fmt.Sprintf("%s/%s", types.AccountTokensInfoPath, addr), cmn.HexBytes(queryBytes)).
+		Return(accTokensInfoBytes, nil)
+	_, err = mockCli.Token().QueryTokenSupply(tokenSymbol)
+	require.Error(t, err)
+
+	mockCli.EXPECT().Query(fmt.Sprintf("custom/%s/info/%s", types.ModuleName, tokenSymbol), nil).
+		Return(nil, errors.New("default error"))
+	_, err = mockCli.Token().QueryTokenSupply(tokenSymbol)
+	require.Error(t, err)
+}
+
+func TestTokenClient_QueryTokenMeta(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := sdk.NewClientConfig("testURL", "testChain", sdk.BroadcastBlock, "0.01okt", 200000)
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewTokenClient(mockCli.MockBaseClient))
+
+	ownerAddr, err := sdk.AccAddressFromBech32(addr)
+	require.NoError(t, err)
+	expectedCdc := mockCli.GetCodec()
+	tokenInfoBytes := mockCli.BuildTokenInfoBytes("default description", tokenSymbol, "btc", "Bitcoin",
+		sdk.OneDec(), sdk.OneDec(), ownerAddr, true, false)
+
+	// query only once and hit the cache later
+	mockCli.EXPECT().GetCodec().Return(expectedCdc)
+	mockCli.EXPECT().Query(fmt.Sprintf("custom/%s/info/%s", types.ModuleName, tokenSymbol), nil).
+		Return(tokenInfoBytes, nil)
+	for i := 0; i < 2; i++ {
+		meta, err := mockCli.Token().QueryTokenMeta(tokenSymbol)
+		require.NoError(t, err)
+		require.Equal(t, types.TokenMeta{Symbol: tokenSymbol, OriginalSymbol: "btc", WholeName: "Bitcoin"}, meta)
+		require.Equal(t, "Bitcoin", meta.DisplayName())
+	}
+
+	// refresh all
+	tokens := []types.Token{{Symbol: "eth-000", OriginalSymbol: "eth"}, {Symbol: "okt"}}
+	mockCli.EXPECT().GetCodec().Return(expectedCdc)
+	mockCli.EXPECT().Query(types.TokensPath, nil).Return(expectedCdc.MustMarshalJSON(tokens), nil)
+	require.NoError(t, mockCli.Token().RefreshTokenMetas())
+
+	meta, err := mockCli.Token().QueryTokenMeta("eth-000")
+	require.NoError(t, err)
+	require.Equal(t, "ETH", meta.DisplayName())
+	meta, err = mockCli.Token().QueryTokenMeta("okt")
+	require.NoError(t, err)
+	require.Equal(t, "okt", meta.DisplayName())
+
+	// the tokens out of the refreshed ones are pruned from the cache
+	mockCli.EXPECT().GetCodec().Return(expectedCdc)
+	mockCli.EXPECT().Query(fmt.Sprintf("custom/%s/info/%s", types.ModuleName, tokenSymbol), nil).
+		Return(tokenInfoBytes, nil)
+	_, err = mockCli.Token().QueryTokenMeta(tokenSymbol)
+	require.NoError(t, err)
+
+	mockCli.EXPECT().Query(fmt.Sprintf("custom/%s/info/%s", types.ModuleName, "xxb-000"), nil).
+		Return(nil, errors.New("default error"))
+	_, err = mockCli.Token().QueryTokenMeta("xxb-000")
+	require.Error(t, err)
+}
+
+func TestTokenMetaCache(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	cache := newTokenMetaCache()
+	cache.now = func() time.Time { return now }
+
+	cache.set(types.Token{Symbol: "eth-000"})
+	_, ok := cache.get("eth-000")
+	require.True(t, ok)
+
+	// expired
+	now = now.Add(tokenMetaTTL + time.Second)
+	_, ok = cache.get("eth-000")
+	require.False(t, ok)
+
+	cache.set(types.Token{Symbol: "eth-000"})
+	cache.reset(types.Token{Symbol: "okt"})
+	_, ok = cache.get("eth-000")
+	require.False(t, ok)
+	_, ok = cache.get("okt")
+	require.True(t, ok)
+
+	tokens, ok := cache.getTokens()
+	require.True(t, ok)
+	require.Equal(t, []types.Token{{Symbol: "okt"}}, tokens)
+	now = now.Add(tokenMetaTTL + time.Second)
+	_, ok = cache.getTokens()
+	require.False(t, ok)
+
+	cache.reset(types.Token{Symbol: "okt"})
+	cache.delete("okt")
+	_, ok = cache.get("okt")
+	require.False(t, ok)
+	_, ok = cache.getTokens()
+	require.False(t, ok)
+}
+
+func TestTokenClient_QueryAccountTokensInfoDec(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := sdk.NewClientConfig("testURL", "testChain", sdk.BroadcastBlock, "0.01okt", 200000)
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewTokenClient(mockCli.MockBaseClient))
+
+	expectedCdc := mockCli.GetCodec()
+	expectedRet := mockCli.BuildAccountTokensInfoBytes(addr, tokenSymbol, "1024.1024", "2048", "10.24")
+	allQueryBytes := expectedCdc.MustMarshalJSON(params.NewQueryAccTokenParams("", "all"))
+	partialQueryBytes := expectedCdc.MustMarshalJSON(params.NewQueryAccTokenParams(tokenSymbol, "partial"))
+
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).Times(4)
+	mockCli.EXPECT().Query(fmt.Sprintf("%s/%s", types.AccountTokensInfoPath, addr), cmn.HexBytes(allQueryBytes)).
+		Return(expectedRet, nil)
+	mockCli.EXPECT().Query(fmt.Sprintf("%s/%s", types.AccountTokensInfoPath, addr), cmn.HexBytes(partialQueryBytes)).
+		Return(expectedRet, nil)
+
+	accTokensInfo, err := mockCli.Token().QueryAccountTokensInfoDec(addr)
+	require.NoError(t, err)
+	require.Equal(t, addr, accTokensInfo.Address)
+	require.Equal(t, tokenSymbol, accTokensInfo.Currencies[0].Symbol)
+	require.Equal(t, "1024.10240000", accTokensInfo.Currencies[0].Available.String())
+	require.Equal(t, "2048.00000000", accTokensInfo.Currencies[0].Freeze.String())
+	require.Equal(t, "10.24000000", accTokensInfo.Currencies[0].Locked.String())
+	require.Equal(t, "3082.34240000", accTokensInfo.Currencies[0].Total.String())
+
+	accTokensInfo, err = mockCli.Token().QueryAccountTokenInfoDec(addr, tokenSymbol)
+	require.NoError(t, err)
+	require.Equal(t, "3082.34240000", accTokensInfo.Currencies[0].Total.String())
+
+	// invalid amount string
+	expectedRet = mockCli.BuildAccountTokensInfoBytes(addr, tokenSymbol, "1024.1024", "2048,2048", "10.24")
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).Times(2)
+	mockCli.EXPECT().Query(fmt.Sprintf("%s/%s", types.AccountTokensInfoPath, addr), cmn.HexBytes(allQueryBytes)).
+		Return(expectedRet, nil)
+	_, err = mockCli.Token().QueryAccountTokensInfoDec(addr)
+	require.Error(t, err)
+
+	_, err = mockCli.Token().QueryAccountTokenInfoDec(addr[1:], tokenSymbol)
+	require.Error(t, err)
+}
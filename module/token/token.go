@@ -10,6 +10,7 @@ var _ sdk.Module = (*tokenClient)(nil)
 
 type tokenClient struct {
 	sdk.BaseClient
+	metaCache *tokenMetaCache
 }
 
 // RegisterCodec registers the msg type in token module
@@ -24,5 +25,5 @@ func (tokenClient) Name() string {
 
 // NewTokenClient creates a new instance of token client as implement
 func NewTokenClient(baseClient sdk.BaseClient) exposed.Token {
-	return tokenClient{baseClient}
+	return tokenClient{baseClient, newTokenMetaCache()}
 }
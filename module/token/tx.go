@@ -6,6 +6,7 @@ import (
 	sdk "github.com/okex/okchain-go-sdk/types"
 	"github.com/okex/okchain-go-sdk/types/crypto/keys"
 	"github.com/okex/okchain-go-sdk/types/params"
+	"github.com/okex/okchain-go-sdk/utils"
 )
 
 // Send transfers coins to other receiver
@@ -56,3 +57,107 @@ func (tc tokenClient) Issue(fromInfo keys.Info, passWd, orgSymbol, wholeName, to
 	return tc.BuildAndBroadcast(fromInfo.GetName(), passWd, memo, []sdk.Msg{msg}, accNum, seqNum)
 
 }
+
+// Mint mints more of a mintable token owned by the sender
+func (tc tokenClient) Mint(fromInfo keys.Info, passWd, symbol string, amount int64, memo string, accNum, seqNum uint64) (
+	resp sdk.TxResponse, err error) {
+	if err = params.CheckTokenMintParams(fromInfo, passWd, symbol, amount); err != nil {
+		return
+	}
+
+	tokens, err := tc.QueryTokenInfo("", symbol)
+	if err != nil {
+		return
+	}
+
+	if len(tokens) == 0 || tokens[0].Symbol != symbol {
+		return resp, fmt.Errorf("failed. token %s doesn't exist", symbol)
+	}
+
+	if !tokens[0].Mintable {
+		return resp, fmt.Errorf("failed. token %s isn't mintable", symbol)
+	}
+
+	if !tokens[0].Owner.Equals(fromInfo.GetAddress()) {
+		return resp, fmt.Errorf("failed. token %s is owned by %s rather than %s", symbol, tokens[0].Owner,
+			fromInfo.GetAddress())
+	}
+
+	msg := types.NewMsgMint(symbol, amount, fromInfo.GetAddress())
+
+	return tc.BuildAndBroadcast(fromInfo.GetName(), passWd, memo, []sdk.Msg{msg}, accNum, seqNum)
+
+}
+
+// Burn burns an amount of token owned by the sender
+func (tc tokenClient) Burn(fromInfo keys.Info, passWd, coinStr, memo string, accNum, seqNum uint64) (resp sdk.TxResponse,
+	err error) {
+	coin, err := params.CheckTokenBurnParams(fromInfo, passWd, coinStr)
+	if err != nil {
+		return
+	}
+
+	msg := types.NewMsgBurn(coin, fromInfo.GetAddress())
+
+	return tc.BuildAndBroadcast(fromInfo.GetName(), passWd, memo, []sdk.Msg{msg}, accNum, seqNum)
+
+}
+
+// Edit edits the description or the whole name of a token owned by the sender
+// NOTE: only the fields with the edit flag on are changed on chain
+func (tc tokenClient) Edit(fromInfo keys.Info, passWd, symbol, tokenDesc, wholeName string, isDescEdit,
+	isWholeNameEdit bool, memo string, accNum, seqNum uint64) (resp sdk.TxResponse, err error) {
+	if err = params.CheckTokenEditParams(fromInfo, passWd, symbol, tokenDesc, wholeName, isDescEdit,
+		isWholeNameEdit); err != nil {
+		return
+	}
+
+	msg := types.NewMsgTokenModify(symbol, tokenDesc, wholeName, isDescEdit, isWholeNameEdit, fromInfo.GetAddress())
+
+	resp, err = tc.BuildAndBroadcast(fromInfo.GetName(), passWd, memo, []sdk.Msg{msg}, accNum, seqNum)
+	if err == nil && isWholeNameEdit {
+		// the cached whole name is out of date
+		tc.metaCache.delete(symbol)
+	}
+
+	return
+
+}
+
+// TransferOwnership signs the multi-signed tx of transfer-ownership from a json file and broadcast
+func (tc tokenClient) TransferOwnership(fromInfo keys.Info, passWd, inputPath string, accNum, seqNum uint64) (
+	resp sdk.TxResponse, err error) {
+	if err = params.CheckKeyParams(fromInfo, passWd); err != nil {
+		return
+	}
+
+	stdTx, err := utils.GetStdTxFromFile(tc.GetCodec(), inputPath)
+	if err != nil {
+		return
+	}
+
+	return tc.BroadcastTransferOwnershipTx(fromInfo, passWd, stdTx, accNum, seqNum)
+}
+
+// BroadcastTransferOwnershipTx signs the multi-signed tx of transfer-ownership in memory and broadcast
+func (tc tokenClient) BroadcastTransferOwnershipTx(fromInfo keys.Info, passWd string, stdTx sdk.StdTx, accNum,
+	seqNum uint64) (resp sdk.TxResponse, err error) {
+	if err = params.CheckKeyParams(fromInfo, passWd); err != nil {
+		return
+	}
+
+	msg, err := getMsgTransferOwnership(stdTx)
+	if err != nil {
+		return
+	}
+
+	if !msg.FromAddress.Equals(fromInfo.GetAddress()) {
+		return resp, fmt.Errorf("failed. only the owner %s is allowed to broadcast", msg.FromAddress)
+	}
+
+	if err = checkToSignature(msg); err != nil {
+		return
+	}
+
+	return tc.BuildAndBroadcast(fromInfo.GetName(), passWd, stdTx.Memo, []sdk.Msg{msg}, accNum, seqNum)
+}
@@ -161,3 +161,169 @@ func TestTokenClient_Issue(t *testing.T) {
 		accInfo.GetSequence())
 	require.Error(t, err)
 }
+
+func TestTokenClient_Mint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := sdk.NewClientConfig("testURL", "testChain", sdk.BroadcastBlock, "0.01okt", 200000)
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewTokenClient(mockCli.MockBaseClient), auth.NewAuthClient(mockCli.MockBaseClient))
+
+	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
+	require.NoError(t, err)
+	recAccAddr, err := sdk.AccAddressFromBech32(recAddr)
+	require.NoError(t, err)
+
+	accBytes := mockCli.BuildAccountBytes(addr, accPubkey, "1024okt", 1, 2)
+	expectedCdc := mockCli.GetCodec()
+	mockCli.EXPECT().GetCodec().Return(expectedCdc).AnyTimes()
+	mockCli.EXPECT().Query(gomock.Any(), gomock.Any()).Return(accBytes, nil)
+
+	accInfo, err := mockCli.Auth().QueryAccount(addr)
+	require.NoError(t, err)
+
+	tokenInfoPath := fmt.Sprintf("custom/%s/info/%s", types.ModuleName, tokenSymbol)
+	supply := sdk.MustNewDecFromStr("10000")
+	mockCli.EXPECT().Query(tokenInfoPath, nil).Return(mockCli.BuildTokenInfoBytes("default description",
+		tokenSymbol, "btc", "default whole name", supply, supply, fromInfo.GetAddress(), true, false), nil)
+	mockCli.EXPECT().BuildAndBroadcast(fromInfo.GetName(), passWd, memo,
+		[]sdk.Msg{types.NewMsgMint(tokenSymbol, 1024, fromInfo.GetAddress())}, accInfo.GetAccountNumber(),
+		accInfo.GetSequence()).Return(mocks.DefaultMockSuccessTxResponse(), nil)
+
+	res, err := mockCli.Token().Mint(fromInfo, passWd, tokenSymbol, 1024, memo, accInfo.GetAccountNumber(),
+		accInfo.GetSequence())
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), res.Code)
+
+	// not mintable
+	mockCli.EXPECT().Query(tokenInfoPath, nil).Return(mockCli.BuildTokenInfoBytes("default description",
+		tokenSymbol, "btc", "default whole name", supply, supply, fromInfo.GetAddress(), false, false), nil)
+	_, err = mockCli.Token().Mint(fromInfo, passWd, tokenSymbol, 1024, memo, accInfo.GetAccountNumber(),
+		accInfo.GetSequence())
+	require.Error(t, err)
+
+	// not the owner
+	mockCli.EXPECT().Query(tokenInfoPath, nil).Return(mockCli.BuildTokenInfoBytes("default description",
+		tokenSymbol, "btc", "default whole name", supply, supply, recAccAddr, true, false), nil)
+	_, err = mockCli.Token().Mint(fromInfo, passWd, tokenSymbol, 1024, memo, accInfo.GetAccountNumber(),
+		accInfo.GetSequence())
+	require.Error(t, err)
+
+	// token not found
+	mockCli.EXPECT().Query(tokenInfoPath, nil).Return(nil, fmt.Errorf("unknown token"))
+	_, err = mockCli.Token().Mint(fromInfo, passWd, tokenSymbol, 1024, memo, accInfo.GetAccountNumber(),
+		accInfo.GetSequence())
+	require.Error(t, err)
+
+	// bad params
+	_, err = mockCli.Token().Mint(fromInfo, "", tokenSymbol, 1024, memo, accInfo.GetAccountNumber(),
+		accInfo.GetSequence())
+	require.Error(t, err)
+	_, err = mockCli.Token().Mint(fromInfo, passWd, "", 1024, memo, accInfo.GetAccountNumber(),
+		accInfo.GetSequence())
+	require.Error(t, err)
+	_, err = mockCli.Token().Mint(fromInfo, passWd, tokenSymbol, 0, memo, accInfo.GetAccountNumber(),
+		accInfo.GetSequence())
+	require.Error(t, err)
+}
+
+func TestTokenClient_Burn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := sdk.NewClientConfig("testURL", "testChain", sdk.BroadcastBlock, "0.01okt", 200000)
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewTokenClient(mockCli.MockBaseClient), auth.NewAuthClient(mockCli.MockBaseClient))
+
+	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
+	require.NoError(t, err)
+
+	accBytes := mockCli.BuildAccountBytes(addr, accPubkey, "1024okt", 1, 2)
+	expectedCdc := mockCli.GetCodec()
+	mockCli.EXPECT().GetCodec().Return(expectedCdc)
+	mockCli.EXPECT().Query(gomock.Any(), gomock.Any()).Return(accBytes, nil)
+
+	accInfo, err := mockCli.Auth().QueryAccount(addr)
+	require.NoError(t, err)
+
+	coin, err := sdk.ParseDecCoin("10.24btc-000")
+	require.NoError(t, err)
+	mockCli.EXPECT().BuildAndBroadcast(fromInfo.GetName(), passWd, memo,
+		[]sdk.Msg{types.NewMsgBurn(coin, fromInfo.GetAddress())}, accInfo.GetAccountNumber(),
+		accInfo.GetSequence()).Return(mocks.DefaultMockSuccessTxResponse(), nil)
+
+	res, err := mockCli.Token().Burn(fromInfo, passWd, "10.24btc-000", memo, accInfo.GetAccountNumber(),
+		accInfo.GetSequence())
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), res.Code)
+
+	_, err = mockCli.Token().Burn(fromInfo, passWd, "10.24", memo, accInfo.GetAccountNumber(), accInfo.GetSequence())
+	require.Error(t, err)
+
+	_, err = mockCli.Token().Burn(fromInfo, passWd, "", memo, accInfo.GetAccountNumber(), accInfo.GetSequence())
+	require.Error(t, err)
+
+	_, err = mockCli.Token().Burn(fromInfo, passWd, "0btc-000", memo, accInfo.GetAccountNumber(), accInfo.GetSequence())
+	require.Error(t, err)
+
+	_, err = mockCli.Token().Burn(fromInfo, "", "10.24btc-000", memo, accInfo.GetAccountNumber(),
+		accInfo.GetSequence())
+	require.Error(t, err)
+}
+
+func TestTokenClient_Edit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	config, err := sdk.NewClientConfig("testURL", "testChain", sdk.BroadcastBlock, "0.01okt", 200000)
+	require.NoError(t, err)
+	mockCli := mocks.NewMockClient(t, ctrl, config)
+	mockCli.RegisterModule(NewTokenClient(mockCli.MockBaseClient), auth.NewAuthClient(mockCli.MockBaseClient))
+
+	fromInfo, _, err := utils.CreateAccountWithMnemo(mnemonic, name, passWd)
+	require.NoError(t, err)
+
+	accBytes := mockCli.BuildAccountBytes(addr, accPubkey, "1024okt", 1, 2)
+	expectedCdc := mockCli.GetCodec()
+	mockCli.EXPECT().GetCodec().Return(expectedCdc)
+	mockCli.EXPECT().Query(gomock.Any(), gomock.Any()).Return(accBytes, nil)
+
+	accInfo, err := mockCli.Auth().QueryAccount(addr)
+	require.NoError(t, err)
+
+	mockCli.EXPECT().BuildAndBroadcast(fromInfo.GetName(), passWd, memo,
+		[]sdk.Msg{types.NewMsgTokenModify(tokenSymbol, "new description", "", true, false, fromInfo.GetAddress())},
+		accInfo.GetAccountNumber(), accInfo.GetSequence()).Return(mocks.DefaultMockSuccessTxResponse(), nil)
+
+	res, err := mockCli.Token().Edit(fromInfo, passWd, tokenSymbol, "new description", "", true, false, memo,
+		accInfo.GetAccountNumber(), accInfo.GetSequence())
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), res.Code)
+
+	// nothing to edit
+	_, err = mockCli.Token().Edit(fromInfo, passWd, tokenSymbol, "new description", "new whole name", false, false,
+		memo, accInfo.GetAccountNumber(), accInfo.GetSequence())
+	require.Error(t, err)
+
+	// empty whole name
+	_, err = mockCli.Token().Edit(fromInfo, passWd, tokenSymbol, "", "", false, true, memo,
+		accInfo.GetAccountNumber(), accInfo.GetSequence())
+	require.Error(t, err)
+
+	// invalid long token description
+	var buffer bytes.Buffer
+	for i := 0; i < 257; i++ {
+		_, _ = buffer.WriteString("a")
+	}
+	_, err = mockCli.Token().Edit(fromInfo, passWd, tokenSymbol, buffer.String(), "", true, false, memo,
+		accInfo.GetAccountNumber(), accInfo.GetSequence())
+	require.Error(t, err)
+
+	_, err = mockCli.Token().Edit(fromInfo, passWd, "", "new description", "", true, false, memo,
+		accInfo.GetAccountNumber(), accInfo.GetSequence())
+	require.Error(t, err)
+
+	_, err = mockCli.Token().Edit(fromInfo, "", tokenSymbol, "new description", "", true, false, memo,
+		accInfo.GetAccountNumber(), accInfo.GetSequence())
+	require.Error(t, err)
+}
@@ -0,0 +1,164 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+
+	sdk "github.com/okex/okchain-go-sdk/types"
+)
+
+// CoinInfoDec - structure for a kind of currencies in AccountTokensInfoDec with the amounts in sdk.Dec
+type CoinInfoDec struct {
+	Symbol    string  `json:"symbol"`
+	Available sdk.Dec `json:"available"`
+	Freeze    sdk.Dec `json:"freeze"`
+	Locked    sdk.Dec `json:"locked"`
+	Total     sdk.Dec `json:"total"`
+}
+
+// AccountTokensInfoDec - structure for available tokens info of an account with the amounts in sdk.Dec
+type AccountTokensInfoDec struct {
+	Address    string        `json:"address"`
+	Currencies []CoinInfoDec `json:"currencies"`
+}
+
+// ToDec converts the coin info to CoinInfoDec and computes the total
+func (ci CoinInfo) ToDec() (coinInfoDec CoinInfoDec, err error) {
+	coinInfoDec.Symbol = ci.Symbol
+	fields := []struct {
+		ptr *sdk.Dec
+		str string
+	}{
+		{&coinInfoDec.Available, ci.Available},
+		{&coinInfoDec.Freeze, ci.Freeze},
+		{&coinInfoDec.Locked, ci.Locked},
+	}
+	for _, field := range fields {
+		if *field.ptr, err = parseAmount(field.str); err != nil {
+			return coinInfoDec, fmt.Errorf("failed. parse amount of %s error: %s", ci.Symbol, err)
+		}
+	}
+
+	coinInfoDec.Total = coinInfoDec.Available.Add(coinInfoDec.Freeze).Add(coinInfoDec.Locked)
+	return
+}
+
+// ToDec converts the account tokens info to AccountTokensInfoDec
+func (ati AccountTokensInfo) ToDec() (accTokensInfoDec AccountTokensInfoDec, err error) {
+	accTokensInfoDec = AccountTokensInfoDec{
+		Address:    ati.Address,
+		Currencies: make([]CoinInfoDec, len(ati.Currencies)),
+	}
+	for i, coinInfo := range ati.Currencies {
+		if accTokensInfoDec.Currencies[i], err = coinInfo.ToDec(); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// BalanceEntry - structure of the balance of a denom in the balance sheet
+type BalanceEntry struct {
+	Denom string `json:"denom"`
+	// Available is the coins owned by the account
+	Available sdk.Dec `json:"available"`
+	Freeze    sdk.Dec `json:"freeze"`
+	// Locked is the locked amount recorded by the token module
+	Locked sdk.Dec `json:"locked"`
+	// OrderLocked is the locked amount computed from the open orders
+	OrderLocked sdk.Dec `json:"order_locked"`
+	Total       sdk.Dec `json:"total"`
+}
+
+// IsConsistent shows whether the locked amount recorded on chain matches the one computed from the open orders
+func (be BalanceEntry) IsConsistent() bool {
+	return be.Locked.Equal(be.OrderLocked)
+}
+
+// BalanceSheet - structure of all the balances of an address
+type BalanceSheet struct {
+	Address string         `json:"address"`
+	Entries []BalanceEntry `json:"entries"`
+}
+
+// NewBalanceSheet merges the account coins, the tokens info and the amounts locked by open orders of an address into a
+// balance sheet ordered by denom. The account coins are taken as the available amounts
+func NewBalanceSheet(accTokensInfo AccountTokensInfoDec, accCoins, orderLocked sdk.DecCoins) BalanceSheet {
+	entries := make(map[string]*BalanceEntry)
+	getEntry := func(denom string) *BalanceEntry {
+		if entry, ok := entries[denom]; ok {
+			return entry
+		}
+
+		entry := &BalanceEntry{
+			Denom:       denom,
+			Available:   sdk.ZeroDec(),
+			Freeze:      sdk.ZeroDec(),
+			Locked:      sdk.ZeroDec(),
+			OrderLocked: sdk.ZeroDec(),
+		}
+		entries[denom] = entry
+		return entry
+	}
+
+	for _, coinInfo := range accTokensInfo.Currencies {
+		entry := getEntry(coinInfo.Symbol)
+		entry.Freeze, entry.Locked = coinInfo.Freeze, coinInfo.Locked
+	}
+
+	for _, coin := range accCoins {
+		getEntry(coin.Denom).Available = coin.Amount
+	}
+
+	for _, coin := range orderLocked {
+		getEntry(coin.Denom).OrderLocked = coin.Amount
+	}
+
+	balanceSheet := BalanceSheet{
+		Address: accTokensInfo.Address,
+		Entries: make([]BalanceEntry, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		entry.Total = entry.Available.Add(entry.Freeze).Add(entry.Locked)
+		balanceSheet.Entries = append(balanceSheet.Entries, *entry)
+	}
+	sort.Slice(balanceSheet.Entries, func(i, j int) bool {
+		return balanceSheet.Entries[i].Denom < balanceSheet.Entries[j].Denom
+	})
+
+	return balanceSheet
+}
+
+// Get gets the balance entry of a denom
+func (bs BalanceSheet) Get(denom string) (BalanceEntry, bool) {
+	i := sort.Search(len(bs.Entries), func(i int) bool {
+		return bs.Entries[i].Denom >= denom
+	})
+	if i < len(bs.Entries) && bs.Entries[i].Denom == denom {
+		return bs.Entries[i], true
+	}
+
+	return BalanceEntry{}, false
+}
+
+// InconsistentDenoms returns the denoms whose locked amounts on chain mismatch the ones computed from the open orders
+// NOTE: the order locked amounts need to be computed over all the open orders of the address, or the amounts locked by
+// the orders left out are reported as inconsistent
+func (bs BalanceSheet) InconsistentDenoms() (denoms []string) {
+	for _, entry := range bs.Entries {
+		if !entry.IsConsistent() {
+			denoms = append(denoms, entry.Denom)
+		}
+	}
+
+	return
+}
+
+func parseAmount(amountStr string) (sdk.Dec, error) {
+	if len(amountStr) == 0 {
+		return sdk.ZeroDec(), nil
+	}
+
+	return sdk.NewDecFromStr(amountStr)
+}
@@ -0,0 +1,21 @@
+package types
+
+import (
+	sdk "github.com/okex/okchain-go-sdk/types"
+)
+
+// const of the events emitted by token module
+const (
+	EventTypeTransfer = "transfer"
+
+	AttributeKeyRecipient = "recipient"
+	AttributeKeySender    = "sender"
+	AttributeKeyAmount    = "amount"
+)
+
+// TransferEvent - structure of the event emitted by a transfer
+type TransferEvent struct {
+	Sender    sdk.AccAddress `json:"sender"`
+	Recipient sdk.AccAddress `json:"recipient"`
+	Amount    sdk.DecCoins   `json:"amount"`
+}
@@ -118,3 +118,90 @@ func (MsgTokenIssue) Route() string                { return "" }
 func (MsgTokenIssue) Type() string                 { return "" }
 func (MsgTokenIssue) ValidateBasic() sdk.Error     { return nil }
 func (MsgTokenIssue) GetSigners() []sdk.AccAddress { return nil }
+
+// MsgBurn - structure to burn an amount of token
+type MsgBurn struct {
+	Amount sdk.DecCoin    `json:"amount"`
+	Owner  sdk.AccAddress `json:"owner"`
+}
+
+// NewMsgBurn is a constructor function for MsgBurn
+func NewMsgBurn(amount sdk.DecCoin, owner sdk.AccAddress) MsgBurn {
+	return MsgBurn{
+		Amount: amount,
+		Owner:  owner,
+	}
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgBurn) GetSignBytes() []byte {
+	return sdk.MustSortJSON(msgCdc.MustMarshalJSON(msg))
+}
+
+// nolint
+func (MsgBurn) Route() string                { return "" }
+func (MsgBurn) Type() string                 { return "" }
+func (MsgBurn) ValidateBasic() sdk.Error     { return nil }
+func (MsgBurn) GetSigners() []sdk.AccAddress { return nil }
+
+// MsgTokenModify - structure to edit the description and the whole name of a token
+type MsgTokenModify struct {
+	Owner                 sdk.AccAddress `json:"owner"`
+	Symbol                string         `json:"symbol"`
+	Description           string         `json:"description"`
+	WholeName             string         `json:"whole_name"`
+	IsDescriptionModified bool           `json:"description_modified"`
+	IsWholeNameModified   bool           `json:"whole_name_modified"`
+}
+
+// NewMsgTokenModify creates a new instance of MsgTokenModify
+func NewMsgTokenModify(symbol, desc, wholeName string, isDescEdit, isWholeNameEdit bool, owner sdk.AccAddress) MsgTokenModify {
+	return MsgTokenModify{
+		Owner:                 owner,
+		Symbol:                symbol,
+		Description:           desc,
+		WholeName:             wholeName,
+		IsDescriptionModified: isDescEdit,
+		IsWholeNameModified:   isWholeNameEdit,
+	}
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgTokenModify) GetSignBytes() []byte {
+	return sdk.MustSortJSON(msgCdc.MustMarshalJSON(msg))
+}
+
+// nolint
+func (MsgTokenModify) Route() string                { return "" }
+func (MsgTokenModify) Type() string                 { return "" }
+func (MsgTokenModify) ValidateBasic() sdk.Error     { return nil }
+func (MsgTokenModify) GetSigners() []sdk.AccAddress { return nil }
+
+// MsgTransferOwnership - structure to change the owner of the token
+type MsgTransferOwnership struct {
+	FromAddress sdk.AccAddress   `json:"from_address"`
+	ToAddress   sdk.AccAddress   `json:"to_address"`
+	Symbol      string           `json:"symbol"`
+	ToSignature sdk.StdSignature `json:"to_signature"`
+}
+
+// NewMsgTransferOwnership creates a msg of changing token's owner
+func NewMsgTransferOwnership(fromAddr, toAddr sdk.AccAddress, symbol string) MsgTransferOwnership {
+	return MsgTransferOwnership{
+		FromAddress: fromAddr,
+		ToAddress:   toAddr,
+		Symbol:      symbol,
+		ToSignature: sdk.StdSignature{},
+	}
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgTransferOwnership) GetSignBytes() []byte {
+	return sdk.MustSortJSON(msgCdc.MustMarshalJSON(msg))
+}
+
+// nolint
+func (MsgTransferOwnership) Route() string                { return "" }
+func (MsgTransferOwnership) Type() string                 { return "" }
+func (MsgTransferOwnership) ValidateBasic() sdk.Error     { return nil }
+func (MsgTransferOwnership) GetSigners() []sdk.AccAddress { return nil }
@@ -0,0 +1,100 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/okex/okchain-go-sdk/types"
+)
+
+// const
+const (
+	// DefaultMaxTransfersPerTx is the default number of the receivers in a single multi-send tx of a batch payout
+	DefaultMaxTransfersPerTx = 100
+
+	PayoutStatusPending     PayoutStatus = ""
+	PayoutStatusSucceeded   PayoutStatus = "succeeded"
+	PayoutStatusFailed      PayoutStatus = "failed"
+	PayoutStatusUnconfirmed PayoutStatus = "unconfirmed"
+)
+
+// PayoutStatus shows the state of a payout record in a batch payout
+type PayoutStatus string
+
+// PayoutRecord - structure of a receiver in a batch payout with its result
+type PayoutRecord struct {
+	To     string       `json:"to"`
+	Amount string       `json:"amount"`
+	Status PayoutStatus `json:"status,omitempty"`
+	TxHash string       `json:"tx_hash,omitempty"`
+	Error  string       `json:"error,omitempty"`
+}
+
+// NewPayoutRecord creates a new instance of PayoutRecord to be paid
+func NewPayoutRecord(toAddrStr, amountStr string) PayoutRecord {
+	return PayoutRecord{
+		To:     toAddrStr,
+		Amount: amountStr,
+	}
+}
+
+// IsDone shows whether the payout record has been paid
+func (pr PayoutRecord) IsDone() bool {
+	return pr.Status == PayoutStatusSucceeded
+}
+
+// IsUnconfirmed shows whether the payout record has been broadcasted in a tx whose result is unknown yet. It's skipped
+// in resuming the batch payout until its tx hash is looked up, so that the receiver won't be paid twice
+func (pr PayoutRecord) IsUnconfirmed() bool {
+	return pr.Status == PayoutStatusUnconfirmed
+}
+
+// ConfirmPayouts resolves the unconfirmed payout records of the tx after looking up its hash. They are set to succeeded
+// if the tx is committed successfully, or back to pending to be paid again otherwise
+func ConfirmPayouts(records []PayoutRecord, txHash string, succeeded bool) {
+	for i := range records {
+		if !records[i].IsUnconfirmed() || records[i].TxHash != txHash {
+			continue
+		}
+
+		if succeeded {
+			records[i].Status, records[i].Error = PayoutStatusSucceeded, ""
+		} else {
+			records[i].Status = PayoutStatusPending
+		}
+	}
+}
+
+// ToTransferUnit validates the receiver and the amount of the payout record and converts it to TransferUnit
+func (pr PayoutRecord) ToTransferUnit() (transfer TransferUnit, err error) {
+	to, err := sdk.AccAddressFromBech32(pr.To)
+	if err != nil {
+		return transfer, fmt.Errorf("failed. parse Address [%s] error: %s", pr.To, err)
+	}
+
+	coins, err := sdk.ParseDecCoins(pr.Amount)
+	if err != nil {
+		return transfer, fmt.Errorf("failed. parse DecCoins [%s] error: %s", pr.Amount, err)
+	}
+
+	if !coins.IsAllPositive() {
+		return transfer, fmt.Errorf("failed. only positive amount of coins is available: %s", pr.Amount)
+	}
+
+	return NewTransferUnit(to, coins), nil
+}
+
+// PayoutConfig - structure of the limits to split a batch payout into multi-send txs
+type PayoutConfig struct {
+	// MaxTransfersPerTx limits the number of the receivers in a single tx. DefaultMaxTransfersPerTx is used if it's 0
+	MaxTransfersPerTx int `json:"max_transfers_per_tx"`
+	// MaxMsgBytes limits the size of the sign bytes of the multi-send msg in a single tx. No limit if it's 0
+	MaxMsgBytes int `json:"max_msg_bytes"`
+}
+
+// NewPayoutConfig creates a new instance of PayoutConfig
+func NewPayoutConfig(maxTransfersPerTx, maxMsgBytes int) PayoutConfig {
+	return PayoutConfig{
+		MaxTransfersPerTx: maxTransfersPerTx,
+		MaxMsgBytes:       maxMsgBytes,
+	}
+}
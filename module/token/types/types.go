@@ -1,6 +1,8 @@
 package types
 
 import (
+	"strings"
+
 	sdk "github.com/okex/okchain-go-sdk/types"
 )
 
@@ -9,6 +11,7 @@ const (
 	ModuleName = "token"
 
 	AccountTokensInfoPath = "custom/token/accounts"
+	TokensPath            = "custom/token/tokens"
 )
 
 var (
@@ -16,6 +19,7 @@ var (
 )
 
 func init() {
+	sdk.RegisterBasicCodec(msgCdc)
 	RegisterCodec(msgCdc)
 }
 
@@ -25,6 +29,9 @@ func RegisterCodec(cdc sdk.SDKCodec) {
 	cdc.RegisterConcrete(MsgMultiSend{}, "okchain/token/MsgMultiTransfer")
 	cdc.RegisterConcrete(MsgTokenIssue{}, "okchain/token/MsgIssue")
 	cdc.RegisterConcrete(MsgMint{}, "okchain/token/MsgMint")
+	cdc.RegisterConcrete(MsgBurn{}, "okchain/token/MsgBurn")
+	cdc.RegisterConcrete(MsgTokenModify{}, "okchain/token/MsgModify")
+	cdc.RegisterConcrete(MsgTransferOwnership{}, "okchain/token/MsgTransferOwnership")
 }
 
 // TransferUnit - amount part for multi-send
@@ -53,6 +60,44 @@ type Token struct {
 	Mintable            bool           `json:"mintable"`
 }
 
+// TokenSupply - structure for the supply info of a kind of token
+type TokenSupply struct {
+	Symbol              string  `json:"symbol"`
+	OriginalTotalSupply sdk.Dec `json:"original_total_supply"`
+	TotalSupply         sdk.Dec `json:"total_supply"`
+	OwnerHoldings       sdk.Dec `json:"owner_holdings"`
+	CirculatingSupply   sdk.Dec `json:"circulating_supply"`
+}
+
+// TokenMeta - structure for the human readable metadata of a kind of token
+type TokenMeta struct {
+	Symbol         string `json:"symbol"`
+	OriginalSymbol string `json:"original_symbol"`
+	WholeName      string `json:"whole_name"`
+}
+
+// NewTokenMeta extracts the TokenMeta from a token
+func NewTokenMeta(token Token) TokenMeta {
+	return TokenMeta{
+		Symbol:         token.Symbol,
+		OriginalSymbol: token.OriginalSymbol,
+		WholeName:      token.WholeName,
+	}
+}
+
+// DisplayName returns the most human readable name of the token
+func (tm TokenMeta) DisplayName() string {
+	if len(tm.WholeName) != 0 {
+		return tm.WholeName
+	}
+
+	if len(tm.OriginalSymbol) != 0 {
+		return strings.ToUpper(tm.OriginalSymbol)
+	}
+
+	return tm.Symbol
+}
+
 // AccountTokensInfo - structure for available tokens info of an account
 type AccountTokensInfo struct {
 	Address    string     `json:"address"`
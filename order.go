@@ -0,0 +1,88 @@
+package gosdk
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/okex/okchain-go-sdk/module/order"
+	"github.com/okex/okchain-go-sdk/types/crypto/keys"
+)
+
+// CancelAllOrders finds all the open orders of the account on the product through the backend and cancels them in as
+// few txs as the chain allows. The orders on both sides are cancelled if side is empty
+func (cli *Client) CancelAllOrders(fromInfo keys.Info, passWd, product, side string) (result CancelOrdersResult,
+	err error) {
+	if fromInfo == nil {
+		return result, errors.New("failed. no key info input")
+	}
+
+	if len(product) == 0 {
+		return result, errors.New("failed. empty product")
+	}
+
+	sides := []string{"BUY", "SELL"}
+	if len(side) != 0 {
+		sides = []string{side}
+	}
+
+	addrStr := fromInfo.GetAddress().String()
+	var orderIDs []string
+	for _, side := range sides {
+		orders, err := cli.Backend().QueryAllOpenOrders(addrStr, product, side, 0, 0, 0)
+		if err != nil {
+			return result, err
+		}
+
+		for _, order := range orders {
+			orderIDs = append(orderIDs, order.OrderID)
+		}
+	}
+
+	if len(orderIDs) == 0 {
+		return
+	}
+
+	acc, err := cli.Auth().QueryAccount(addrStr)
+	if err != nil {
+		return
+	}
+
+	return cli.Order().CancelOrdersInBatches(fromInfo, passWd, orderIDs, "", acc.GetAccountNumber(), acc.GetSequence())
+}
+
+// EstimateOrder estimates the expiry, the max fees and the locked amount of the order item if it's placed on the next
+// block. The block interval is averaged over the latest sampleBlocks blocks
+func (cli *Client) EstimateOrder(orderItem OrderItem, sampleBlocks int64) (estimate OrderEstimate, err error) {
+	if sampleBlocks <= 0 {
+		return estimate, fmt.Errorf("failed. invalid sample blocks %d", sampleBlocks)
+	}
+
+	orderParams, err := cli.Order().QueryParams()
+	if err != nil {
+		return
+	}
+
+	latestHeight, err := cli.Tendermint().QueryLatestHeight()
+	if err != nil {
+		return
+	}
+
+	if latestHeight <= sampleBlocks {
+		return estimate, fmt.Errorf("failed. sample blocks %d exceed the latest height %d", sampleBlocks, latestHeight)
+	}
+
+	latestBlock, err := cli.Tendermint().QueryBlock(latestHeight)
+	if err != nil {
+		return
+	}
+
+	sampleBlock, err := cli.Tendermint().QueryBlock(latestHeight - sampleBlocks)
+	if err != nil {
+		return
+	}
+
+	blockInterval := latestBlock.Time.Sub(sampleBlock.Time) / time.Duration(sampleBlocks)
+	return order.NewOrderEstimate(orderItem, orderParams, latestHeight+1, latestBlock.Time.Add(blockInterval),
+		blockInterval)
+}
@@ -0,0 +1,356 @@
+package orderbook
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	ordertypes "github.com/okex/okchain-go-sdk/module/order/types"
+	sdk "github.com/okex/okchain-go-sdk/types"
+)
+
+// Book is the local order book of a product. It's safe for concurrent use
+type Book struct {
+	product string
+
+	mtx    sync.RWMutex
+	bids   map[string]Level
+	asks   map[string]Level
+	orders map[string]bookOrder
+	height int64
+	// snapshotHeight is the height of the depth book snapshot, whose blocks and the earlier ones are in the levels
+	snapshotHeight int64
+	stale          bool
+}
+
+// NewBook creates a new instance of Book seeded from the depth book snapshot on the height. The blocks on the height
+// and below don't change the levels any more. No block is skipped if the height is unknown as zero
+func NewBook(product string, bookRes ordertypes.BookRes, height int64) (*Book, error) {
+	book := &Book{
+		product: product,
+		orders:  make(map[string]bookOrder),
+	}
+	if err := book.Reset(bookRes, height); err != nil {
+		return nil, err
+	}
+
+	return book, nil
+}
+
+// Reset replaces all the levels with the depth book snapshot on the height and clears the stale flag. The orders placed
+// after the book was seeded are kept, so that their cancellations and fills still apply
+func (b *Book) Reset(bookRes ordertypes.BookRes, height int64) error {
+	bids, err := parseLevels(bookRes.Bids)
+	if err != nil {
+		return fmt.Errorf("failed. parse bids of %s error: %s", b.product, err)
+	}
+
+	asks, err := parseLevels(bookRes.Asks)
+	if err != nil {
+		return fmt.Errorf("failed. parse asks of %s error: %s", b.product, err)
+	}
+
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.bids, b.asks, b.stale = bids, asks, false
+	if height > b.snapshotHeight {
+		b.snapshotHeight = height
+	}
+	return nil
+}
+
+// Product returns the product of the book
+func (b *Book) Product() string {
+	return b.product
+}
+
+// Height returns the height of the last block applied to the book
+func (b *Book) Height() int64 {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return b.height
+}
+
+// IsStale shows whether the book missed a change that can't be applied from the events and needs a resync
+func (b *Book) IsStale() bool {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return b.stale
+}
+
+// BestBid returns the bid level with the highest price
+func (b *Book) BestBid() (Level, bool) {
+	return firstLevel(b.Bids(1))
+}
+
+// BestAsk returns the ask level with the lowest price
+func (b *Book) BestAsk() (Level, bool) {
+	return firstLevel(b.Asks(1))
+}
+
+// Bids returns the bid levels from the highest price. All levels return if depth is non-positive
+func (b *Book) Bids(depth int) []Level {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return sortLevels(b.bids, depth, func(p1, p2 sdk.Dec) bool {
+		return p1.GT(p2)
+	})
+}
+
+// Asks returns the ask levels from the lowest price. All levels return if depth is non-positive
+func (b *Book) Asks(depth int) []Level {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return sortLevels(b.asks, depth, func(p1, p2 sdk.Dec) bool {
+		return p1.LT(p2)
+	})
+}
+
+// DepthAt returns the quantity on the price of the side. It's zero if there's no such level
+func (b *Book) DepthAt(side ordertypes.Side, price sdk.Dec) sdk.Dec {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	if level, ok := b.levels(side.String())[price.String()]; ok {
+		return level.Quantity
+	}
+
+	return sdk.ZeroDec()
+}
+
+// VWAP returns the volume weighted average price to take the quantity from the book by a taker on the side. A BUY
+// taker walks the asks from the lowest price and a SELL taker walks the bids from the highest price
+func (b *Book) VWAP(side ordertypes.Side, quantity sdk.Dec) (sdk.Dec, error) {
+	if !side.IsValid() {
+		return sdk.Dec{}, fmt.Errorf("failed. invalid side %s", side)
+	}
+
+	if quantity.IsNil() || !quantity.IsPositive() {
+		return sdk.Dec{}, errors.New("failed. quantity must be positive")
+	}
+
+	var levels []Level
+	if side == ordertypes.SideBuy {
+		levels = b.Asks(0)
+	} else {
+		levels = b.Bids(0)
+	}
+
+	amount, rest := sdk.ZeroDec(), quantity
+	for _, level := range levels {
+		taken := level.Quantity
+		if taken.GT(rest) {
+			taken = rest
+		}
+
+		amount, rest = amount.Add(level.Price.Mul(taken)), rest.Sub(taken)
+		if rest.IsZero() {
+			return amount.Quo(quantity), nil
+		}
+	}
+
+	return sdk.Dec{}, fmt.Errorf("failed. insufficient depth of %s for %s %s", b.product, side, quantity)
+}
+
+// appliesTo shows whether the block on the height changes the levels, which are in the snapshot if it's not after the
+// snapshot height. The orders are tracked in both cases
+func (b *Book) appliesTo(height int64) bool {
+	return height > b.snapshotHeight
+}
+
+// addOrder adds a new order placed in the block on the height to the book
+func (b *Book) addOrder(orderID string, orderItem ordertypes.OrderItem, height int64) {
+	side := orderItem.Side.String()
+	b.orders[orderID] = bookOrder{side: side, price: orderItem.Price, remain: orderItem.Quantity, height: height}
+	if b.appliesTo(height) {
+		b.changeLevel(side, orderItem.Price, orderItem.Quantity)
+	}
+}
+
+// cancelOrder removes the rest of an order cancelled on the height from the book. It returns false if the order is
+// unknown
+func (b *Book) cancelOrder(orderID string, height int64) bool {
+	order, ok := b.orders[orderID]
+	if !ok {
+		return false
+	}
+
+	delete(b.orders, orderID)
+	if b.appliesTo(height) {
+		b.changeLevel(order.side, order.price, order.remain.Neg())
+	}
+	return true
+}
+
+// fillOrders removes the quantities filled at the end of the block on the height from the levels. The fill of a known
+// order is removed from the level of its price. The orders seeded from the snapshot have unknown prices, but all the
+// orders are matched in price priority, so their fills are taken from the levels of the side from the best price to
+// the fill price. The book turns stale only if the levels are short of the fills
+func (b *Book) fillOrders(fillEvents []ordertypes.FillEvent, height int64) {
+	var seededFills []ordertypes.FillEvent
+	for _, fillEvent := range fillEvents {
+		if !b.fillOrder(fillEvent, height) {
+			seededFills = append(seededFills, fillEvent)
+		}
+	}
+
+	if !b.appliesTo(height) {
+		return
+	}
+
+	for _, fillEvent := range seededFills {
+		if !b.takeLevels(fillEvent.Side, fillEvent.Price, fillEvent.Quantity) {
+			b.stale = true
+		}
+	}
+}
+
+// fillOrder removes the filled quantity of a known order from its price level. It returns false if the order is
+// unknown
+func (b *Book) fillOrder(fillEvent ordertypes.FillEvent, height int64) bool {
+	order, ok := b.orders[fillEvent.OrderID]
+	if !ok {
+		return false
+	}
+
+	filled := fillEvent.Quantity
+	if filled.GT(order.remain) {
+		filled = order.remain
+	}
+
+	order.remain = order.remain.Sub(filled)
+	if order.remain.IsPositive() {
+		b.orders[fillEvent.OrderID] = order
+	} else {
+		delete(b.orders, fillEvent.OrderID)
+	}
+	if b.appliesTo(height) {
+		b.changeLevel(order.side, order.price, filled.Neg())
+	}
+	return true
+}
+
+// takeLevels removes the quantity from the levels of the side that can be filled at the price in price priority. It
+// returns false if the levels are short of the quantity
+func (b *Book) takeLevels(side string, price, quantity sdk.Dec) bool {
+	var levels []Level
+	switch side {
+	case ordertypes.SideBuy.String():
+		levels = sortLevels(b.bids, 0, func(p1, p2 sdk.Dec) bool {
+			return p1.GT(p2)
+		})
+	case ordertypes.SideSell.String():
+		levels = sortLevels(b.asks, 0, func(p1, p2 sdk.Dec) bool {
+			return p1.LT(p2)
+		})
+	default:
+		return false
+	}
+
+	rest := quantity
+	for _, level := range levels {
+		if !rest.IsPositive() || (side == ordertypes.SideBuy.String() && level.Price.LT(price)) ||
+			(side == ordertypes.SideSell.String() && level.Price.GT(price)) {
+			break
+		}
+
+		taken := level.Quantity
+		if taken.GT(rest) {
+			taken = rest
+		}
+		b.changeLevel(side, level.Price, taken.Neg())
+		rest = rest.Sub(taken)
+	}
+
+	return !rest.IsPositive()
+}
+
+// expireOrders removes the rest of the orders expired by the end of the block on the height. The expiry of the orders
+// seeded from the snapshot has no event, which is caught up by the periodic resync
+func (b *Book) expireOrders(height, expireBlocks int64) {
+	for orderID, order := range b.orders {
+		if order.height+expireBlocks > height {
+			continue
+		}
+
+		delete(b.orders, orderID)
+		if b.appliesTo(height) {
+			b.changeLevel(order.side, order.price, order.remain.Neg())
+		}
+	}
+}
+
+func (b *Book) changeLevel(side string, price, delta sdk.Dec) {
+	levels := b.levels(side)
+	if levels == nil {
+		return
+	}
+
+	key := price.String()
+	quantity := delta
+	if level, ok := levels[key]; ok {
+		quantity = level.Quantity.Add(delta)
+	}
+
+	if quantity.IsPositive() {
+		levels[key] = Level{Price: price, Quantity: quantity}
+	} else {
+		delete(levels, key)
+	}
+}
+
+func (b *Book) levels(side string) map[string]Level {
+	switch side {
+	case ordertypes.SideBuy.String():
+		return b.bids
+	case ordertypes.SideSell.String():
+		return b.asks
+	default:
+		return nil
+	}
+}
+
+func parseLevels(items []ordertypes.BookResItem) (map[string]Level, error) {
+	levels := make(map[string]Level, len(items))
+	for _, item := range items {
+		price, err := sdk.NewDecFromStr(item.Price)
+		if err != nil {
+			return nil, fmt.Errorf("invalid price %s: %s", item.Price, err)
+		}
+
+		quantity, err := sdk.NewDecFromStr(item.Quantity)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quantity %s: %s", item.Quantity, err)
+		}
+
+		if quantity.IsPositive() {
+			levels[price.String()] = Level{Price: price, Quantity: quantity}
+		}
+	}
+
+	return levels, nil
+}
+
+func sortLevels(levelMap map[string]Level, depth int, less func(p1, p2 sdk.Dec) bool) []Level {
+	levels := make([]Level, 0, len(levelMap))
+	for _, level := range levelMap {
+		levels = append(levels, level)
+	}
+	sort.Slice(levels, func(i, j int) bool {
+		return less(levels[i].Price, levels[j].Price)
+	})
+
+	if depth > 0 && depth < len(levels) {
+		levels = levels[:depth]
+	}
+
+	return levels
+}
+
+func firstLevel(levels []Level) (Level, bool) {
+	if len(levels) == 0 {
+		return Level{}, false
+	}
+
+	return levels[0], true
+}
@@ -0,0 +1,122 @@
+package orderbook
+
+import (
+	"testing"
+
+	ordertypes "github.com/okex/okchain-go-sdk/module/order/types"
+	sdk "github.com/okex/okchain-go-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+const product = "btc-000_okt"
+
+func newBookRes() ordertypes.BookRes {
+	return ordertypes.BookRes{
+		Asks: []ordertypes.BookResItem{
+			{Price: "10.3", Quantity: "3"},
+			{Price: "10.1", Quantity: "1"},
+			{Price: "10.2", Quantity: "2"},
+		},
+		Bids: []ordertypes.BookResItem{
+			{Price: "9.8", Quantity: "2"},
+			{Price: "9.9", Quantity: "1"},
+			{Price: "9.7", Quantity: "0"},
+		},
+	}
+}
+
+func TestBook(t *testing.T) {
+	book, err := NewBook(product, newBookRes(), 0)
+	require.NoError(t, err)
+	require.Equal(t, product, book.Product())
+	require.False(t, book.IsStale())
+
+	bestBid, ok := book.BestBid()
+	require.True(t, ok)
+	require.Equal(t, sdk.MustNewDecFromStr("9.9"), bestBid.Price)
+	bestAsk, ok := book.BestAsk()
+	require.True(t, ok)
+	require.Equal(t, sdk.MustNewDecFromStr("10.1"), bestAsk.Price)
+
+	// the empty level is dropped
+	require.Equal(t, 2, len(book.Bids(0)))
+	asks := book.Asks(2)
+	require.Equal(t, 2, len(asks))
+	require.Equal(t, sdk.MustNewDecFromStr("10.2"), asks[1].Price)
+
+	require.Equal(t, sdk.MustNewDecFromStr("2"), book.DepthAt(ordertypes.SideSell, sdk.MustNewDecFromStr("10.2")))
+	require.True(t, book.DepthAt(ordertypes.SideBuy, sdk.MustNewDecFromStr("10.2")).IsZero())
+
+	// (10.1*1 + 10.2*2 + 10.3*1) / 4
+	vwap, err := book.VWAP(ordertypes.SideBuy, sdk.MustNewDecFromStr("4"))
+	require.NoError(t, err)
+	require.Equal(t, sdk.MustNewDecFromStr("10.2"), vwap)
+	// (9.9*1 + 9.8*1) / 2
+	vwap, err = book.VWAP(ordertypes.SideSell, sdk.MustNewDecFromStr("2"))
+	require.NoError(t, err)
+	require.Equal(t, sdk.MustNewDecFromStr("9.85"), vwap)
+
+	_, err = book.VWAP(ordertypes.SideSell, sdk.MustNewDecFromStr("3.1"))
+	require.Error(t, err)
+	_, err = book.VWAP(ordertypes.SideSell, sdk.ZeroDec())
+	require.Error(t, err)
+	_, err = book.VWAP("buy", sdk.OneDec())
+	require.Error(t, err)
+
+	// orders placed, filled and cancelled after the seeding
+	book.addOrder("ID1", ordertypes.NewOrderItemDec(product, ordertypes.SideBuy, sdk.MustNewDecFromStr("10"),
+		sdk.MustNewDecFromStr("5")), 1)
+	bestBid, _ = book.BestBid()
+	require.Equal(t, Level{Price: sdk.MustNewDecFromStr("10"), Quantity: sdk.MustNewDecFromStr("5")}, bestBid)
+
+	book.fillOrders([]ordertypes.FillEvent{{OrderID: "ID1", Product: product, Side: "BUY",
+		Price: sdk.MustNewDecFromStr("9.95"), Quantity: sdk.MustNewDecFromStr("2")}}, 2)
+	require.Equal(t, sdk.MustNewDecFromStr("3"), book.DepthAt(ordertypes.SideBuy, sdk.MustNewDecFromStr("10")))
+	require.False(t, book.IsStale())
+
+	require.True(t, book.cancelOrder("ID1", 3))
+	require.False(t, book.cancelOrder("ID1", 3))
+	bestBid, _ = book.BestBid()
+	require.Equal(t, sdk.MustNewDecFromStr("9.9"), bestBid.Price)
+
+	// the fills of the seeded orders are taken from the levels in price priority within the fill price
+	book.fillOrders([]ordertypes.FillEvent{
+		{OrderID: "ID0-1", Product: product, Side: "SELL", Price: sdk.MustNewDecFromStr("10.2"),
+			Quantity: sdk.MustNewDecFromStr("1.5")},
+		{OrderID: "ID0-2", Product: product, Side: "BUY", Price: sdk.MustNewDecFromStr("9.9"),
+			Quantity: sdk.MustNewDecFromStr("1")},
+	}, 4)
+	require.False(t, book.IsStale())
+	require.True(t, book.DepthAt(ordertypes.SideSell, sdk.MustNewDecFromStr("10.1")).IsZero())
+	require.Equal(t, sdk.MustNewDecFromStr("1.5"), book.DepthAt(ordertypes.SideSell, sdk.MustNewDecFromStr("10.2")))
+	require.True(t, book.DepthAt(ordertypes.SideBuy, sdk.MustNewDecFromStr("9.9")).IsZero())
+	require.Equal(t, sdk.MustNewDecFromStr("2"), book.DepthAt(ordertypes.SideBuy, sdk.MustNewDecFromStr("9.8")))
+
+	// the levels short of the fill
+	book.fillOrders([]ordertypes.FillEvent{{OrderID: "ID0-3", Product: product, Side: "SELL",
+		Price: sdk.MustNewDecFromStr("10.2"), Quantity: sdk.MustNewDecFromStr("2")}}, 5)
+	require.True(t, book.IsStale())
+
+	// the blocks in the snapshot only track the orders
+	require.NoError(t, book.Reset(newBookRes(), 7))
+	require.False(t, book.IsStale())
+	book.addOrder("ID6", ordertypes.NewOrderItemDec(product, ordertypes.SideSell, sdk.MustNewDecFromStr("10.1"),
+		sdk.MustNewDecFromStr("2")), 6)
+	require.Equal(t, sdk.OneDec(), book.DepthAt(ordertypes.SideSell, sdk.MustNewDecFromStr("10.1")))
+	book.fillOrders([]ordertypes.FillEvent{{OrderID: "ID6", Product: product, Side: "SELL",
+		Price: sdk.MustNewDecFromStr("10.1"), Quantity: sdk.OneDec()}}, 7)
+	require.Equal(t, sdk.OneDec(), book.DepthAt(ordertypes.SideSell, sdk.MustNewDecFromStr("10.1")))
+	require.True(t, book.cancelOrder("ID6", 8))
+	require.True(t, book.DepthAt(ordertypes.SideSell, sdk.MustNewDecFromStr("10.1")).IsZero())
+	require.False(t, book.IsStale())
+
+	_, err = NewBook(product, ordertypes.BookRes{Asks: []ordertypes.BookResItem{{Price: "1.0.1", Quantity: "1"}}}, 0)
+	require.Error(t, err)
+	_, err = NewBook(product, ordertypes.BookRes{Bids: []ordertypes.BookResItem{{Price: "1", Quantity: "one"}}}, 0)
+	require.Error(t, err)
+
+	emptyBook, err := NewBook(product, ordertypes.BookRes{}, 0)
+	require.NoError(t, err)
+	_, ok = emptyBook.BestAsk()
+	require.False(t, ok)
+}
@@ -0,0 +1,375 @@
+package orderbook
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/okex/okchain-go-sdk/exposed"
+	ordertypes "github.com/okex/okchain-go-sdk/module/order/types"
+	"github.com/okex/okchain-go-sdk/module/tendermint"
+	tmtypes "github.com/okex/okchain-go-sdk/module/tendermint/types"
+	sdk "github.com/okex/okchain-go-sdk/types"
+	"github.com/okex/okchain-go-sdk/utils"
+)
+
+// maxSnapshotTries caps the tries to take a depth book snapshot with the latest height unchanged around it
+const maxSnapshotTries = 3
+
+// Maintainer keeps the local order books of the products current. The books are seeded from the depth book snapshots,
+// updated by the new, cancel and fill events of each block, and resynced periodically or once they turn stale
+type Maintainer struct {
+	oq     exposed.OrderQuery
+	tq     exposed.TendermintQuery
+	config Config
+
+	mtx      sync.Mutex
+	books    map[string]*Book
+	iterator *tendermint.BlockIterator
+	stopped  bool
+}
+
+// NewMaintainer creates a new instance of Maintainer
+func NewMaintainer(oq exposed.OrderQuery, tq exposed.TendermintQuery, config Config) *Maintainer {
+	return &Maintainer{
+		oq:     oq,
+		tq:     tq,
+		config: config,
+		books:  make(map[string]*Book),
+	}
+}
+
+// Add seeds the books of the products from their depth book snapshots. The products already added are skipped
+func (m *Maintainer) Add(products ...string) error {
+	for _, product := range products {
+		if len(product) == 0 {
+			return errors.New("failed. empty product")
+		}
+
+		if _, ok := m.Book(product); ok {
+			continue
+		}
+
+		bookRes, height, err := m.snapshot(product)
+		if err != nil {
+			return err
+		}
+
+		book, err := NewBook(product, bookRes, height)
+		if err != nil {
+			return err
+		}
+
+		m.mtx.Lock()
+		m.books[product] = book
+		m.mtx.Unlock()
+	}
+
+	return nil
+}
+
+// Remove stops maintaining the books of the products
+func (m *Maintainer) Remove(products ...string) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	for _, product := range products {
+		delete(m.books, product)
+	}
+}
+
+// Book gets the local order book of a product
+func (m *Maintainer) Book(product string) (*Book, bool) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	book, ok := m.books[product]
+	return book, ok
+}
+
+// Products returns the products of all the books in order
+func (m *Maintainer) Products() []string {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	products := make([]string, 0, len(m.books))
+	for product := range m.books {
+		products = append(products, product)
+	}
+	sort.Strings(products)
+
+	return products
+}
+
+// Resync resets the books from their depth book snapshots. All the books are resynced if onlyStale is false
+func (m *Maintainer) Resync(onlyStale bool) error {
+	for _, product := range m.Products() {
+		book, ok := m.Book(product)
+		if !ok || (onlyStale && !book.IsStale()) {
+			continue
+		}
+
+		bookRes, height, err := m.snapshot(product)
+		if err != nil {
+			return err
+		}
+
+		if err = book.Reset(bookRes, height); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// snapshot queries the depth book of the product with the height it's taken on, which is known only if the latest
+// height stays unchanged around the query. The height is zero without the tendermint query client
+func (m *Maintainer) snapshot(product string) (bookRes ordertypes.BookRes, height int64, err error) {
+	if m.tq == nil {
+		bookRes, err = m.oq.QueryDepthBook(product)
+		return
+	}
+
+	for i := 0; i < maxSnapshotTries; i++ {
+		if height, err = m.tq.QueryLatestHeight(); err != nil {
+			return
+		}
+
+		if bookRes, err = m.oq.QueryDepthBook(product); err != nil {
+			return
+		}
+
+		latestHeight, err := m.tq.QueryLatestHeight()
+		if err != nil {
+			return bookRes, 0, err
+		}
+
+		if latestHeight == height {
+			return bookRes, height, nil
+		}
+	}
+
+	return bookRes, 0, fmt.Errorf("failed. latest height keeps changing in the snapshot of %s", product)
+}
+
+// ProcessBlock applies the orders placed and cancelled in the successful txs, and then the fills and the expiry at the
+// end of the block to the books. The levels of a book aren't changed by the blocks in its snapshot, where only its
+// orders are tracked. The books turn stale instead of failing the block on the malformed events
+func (m *Maintainer) ProcessBlock(item tmtypes.BlockWithResults) error {
+	block, deliverTxs := item.Block, item.Results.Results.DeliverTx
+	if len(block.Txs) != len(deliverTxs) {
+		return fmt.Errorf("failed. %d txs but %d results on height %d", len(block.Txs), len(deliverTxs),
+			block.Height)
+	}
+
+	m.mtx.Lock()
+	expireBlocks := m.config.OrderExpireBlocks
+	m.mtx.Unlock()
+
+	books := m.lockBooks()
+	defer unlockBooks(books)
+
+	for i, stdTx := range block.Txs {
+		if deliverTxs[i].Code != 0 {
+			continue
+		}
+
+		applyTx(books, stdTx.Msgs, deliverTxs[i], block.Height)
+	}
+
+	fillEvents := make(map[string][]ordertypes.FillEvent)
+	for _, event := range utils.GetEventsFromABCIEvents(item.Results.Results.EndBlock.Events) {
+		events, err := utils.ParseFillEvents(sdk.StringEvents{event})
+		if err != nil {
+			markStale(books, block.Height)
+			continue
+		}
+
+		for _, fillEvent := range events {
+			fillEvents[fillEvent.Product] = append(fillEvents[fillEvent.Product], fillEvent)
+		}
+	}
+
+	for product, book := range books {
+		book.fillOrders(fillEvents[product], block.Height)
+	}
+
+	for _, book := range books {
+		if expireBlocks > 0 {
+			book.expireOrders(block.Height, expireBlocks)
+		}
+		book.height = block.Height
+	}
+
+	return nil
+}
+
+// Run follows the blocks from the start height until Stop is called or an error occurs. The stale books are resynced
+// after each block and all the books are resynced every ResyncBlocks blocks
+func (m *Maintainer) Run() error {
+	if m.tq == nil {
+		return errors.New("failed. no tendermint query client to follow the blocks")
+	}
+
+	m.mtx.Lock()
+	expireBlocks := m.config.OrderExpireBlocks
+	m.mtx.Unlock()
+	if expireBlocks <= 0 {
+		orderParams, err := m.oq.QueryParams()
+		if err != nil {
+			return err
+		}
+
+		m.mtx.Lock()
+		m.config.OrderExpireBlocks = orderParams.OrderExpireBlocks
+		m.mtx.Unlock()
+	}
+
+	startHeight := m.config.StartHeight
+	if startHeight <= 0 {
+		latestHeight, err := m.tq.QueryLatestHeight()
+		if err != nil {
+			return err
+		}
+		startHeight = latestHeight + 1
+	}
+
+	iteratorConfig := tendermint.NewBlockIteratorConfig(startHeight, 0, true)
+	if m.config.PollInterval > 0 {
+		iteratorConfig.PollInterval = m.config.PollInterval
+	}
+
+	m.mtx.Lock()
+	if m.stopped {
+		m.mtx.Unlock()
+		return nil
+	}
+	iterator, err := tendermint.NewBlockIterator(m.tq, iteratorConfig)
+	if err != nil {
+		m.mtx.Unlock()
+		return err
+	}
+	m.iterator = iterator
+	m.mtx.Unlock()
+
+	for blocks := int64(1); iterator.Next(); blocks++ {
+		if err = m.ProcessBlock(iterator.Value()); err != nil {
+			iterator.Close()
+			return err
+		}
+
+		onlyStale := m.config.ResyncBlocks <= 0 || blocks%m.config.ResyncBlocks != 0
+		if err = m.Resync(onlyStale); err != nil {
+			iterator.Close()
+			return err
+		}
+	}
+
+	return iterator.Err()
+}
+
+// Stop stops the running maintainer
+func (m *Maintainer) Stop() {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.stopped = true
+	if m.iterator != nil {
+		m.iterator.Close()
+	}
+}
+
+// lockBooks locks all the books for writing, so that a block is applied to them atomically
+func (m *Maintainer) lockBooks() map[string]*Book {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	books := make(map[string]*Book, len(m.books))
+	for product, book := range m.books {
+		book.mtx.Lock()
+		books[product] = book
+	}
+
+	return books
+}
+
+func unlockBooks(books map[string]*Book) {
+	for _, book := range books {
+		book.mtx.Unlock()
+	}
+}
+
+// applyTx applies the orders placed and cancelled in a successful tx on the height. The new orders are paired with
+// their results in the events by index, and the books turn stale if they can't be paired
+func applyTx(books map[string]*Book, msgs []sdk.Msg, deliverTx tmtypes.ResponseDeliverTx, height int64) {
+	events := utils.GetEventsFromDeliverTx(deliverTx)
+	var orderItems []ordertypes.OrderItem
+	for _, msg := range msgs {
+		if msg, ok := msg.(ordertypes.MsgNewOrders); ok {
+			orderItems = append(orderItems, msg.OrderItems...)
+		}
+	}
+
+	if len(orderItems) != 0 {
+		// the malformed results can't be paired either
+		results, _ := getOrderResults(events, utils.ParseNewOrderEvents)
+		for i, orderItem := range orderItems {
+			book, ok := books[orderItem.Product]
+			if !ok {
+				continue
+			}
+
+			if len(results) != len(orderItems) {
+				book.stale = book.stale || book.appliesTo(height)
+			} else if results[i].Code == 0 && len(results[i].OrderID) != 0 {
+				book.addOrder(results[i].OrderID, orderItem, height)
+			}
+		}
+	}
+
+	results, err := getOrderResults(events, utils.ParseCancelOrderEvents)
+	if err != nil {
+		markStale(books, height)
+		return
+	}
+
+	// the product of a cancelled order is unknown from the event, so the cancellation of an order placed before the
+	// seeding turns all the books stale
+	for _, result := range results {
+		if result.Code != 0 {
+			continue
+		}
+
+		if !cancelOrder(books, result.OrderID, height) {
+			markStale(books, height)
+		}
+	}
+}
+
+// cancelOrder removes the cancelled order from the book it's found in. It returns false if no book knows the order
+func cancelOrder(books map[string]*Book, orderID string, height int64) bool {
+	for _, book := range books {
+		if book.cancelOrder(orderID, height) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// markStale turns the books stale for the changes on the height, except the ones whose snapshot covers the height
+func markStale(books map[string]*Book, height int64) {
+	for _, book := range books {
+		book.stale = book.stale || book.appliesTo(height)
+	}
+}
+
+func getOrderResults(events sdk.StringEvents, parse func(sdk.StringEvents) ([]ordertypes.OrdersEvent, error)) (
+	results []ordertypes.OrderResult, err error) {
+	ordersEvents, err := parse(events)
+	if err != nil {
+		return
+	}
+
+	for _, ordersEvent := range ordersEvents {
+		results = append(results, ordersEvent.Results...)
+	}
+
+	return
+}
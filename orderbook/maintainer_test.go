@@ -0,0 +1,238 @@
+package orderbook
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/okex/okchain-go-sdk/exposed"
+	ordertypes "github.com/okex/okchain-go-sdk/module/order/types"
+	tmtypes "github.com/okex/okchain-go-sdk/module/tendermint/types"
+	sdk "github.com/okex/okchain-go-sdk/types"
+	"github.com/stretchr/testify/require"
+	tmbasetypes "github.com/tendermint/tendermint/types"
+)
+
+const addr = "okchain1dcsxvxgj374dv3wt9szflf9nz6342juzzkjnlz"
+
+type orderQuery struct {
+	exposed.OrderQuery
+	books   map[string]ordertypes.BookRes
+	queries int
+}
+
+func (oq *orderQuery) QueryDepthBook(product string) (ordertypes.BookRes, error) {
+	oq.queries++
+	bookRes, ok := oq.books[product]
+	if !ok {
+		return bookRes, errors.New("product not found")
+	}
+
+	return bookRes, nil
+}
+
+func newEvent(eventType string, kvs ...string) tmtypes.Event {
+	event := tmtypes.Event{Type: eventType}
+	for i := 0; i < len(kvs); i += 2 {
+		event.Attributes = append(event.Attributes, tmtypes.KVPair{Key: []byte(kvs[i]), Value: []byte(kvs[i+1])})
+	}
+
+	return event
+}
+
+// newOrdersEvents builds the message events of an order msg in the layout of the raw abci events, where the action
+// and the orders are split into different events
+func newOrdersEvents(action, orders string) []tmtypes.Event {
+	return []tmtypes.Event{
+		newEvent("message", "action", action),
+		newEvent("message", "module", "order", "sender", addr),
+		newEvent("message", "orders", orders),
+	}
+}
+
+func newFillEvent(orderID, side, price, quantity string) tmtypes.Event {
+	return newEvent("fill", "order_id", orderID, "product", product, "side", side, "price", price,
+		"quantity", quantity, "fee", "")
+}
+
+func newBlockWithResults(height int64, stdTxs []sdk.StdTx, deliverTxs []tmtypes.ResponseDeliverTx,
+	endBlockEvents []tmtypes.Event) tmtypes.BlockWithResults {
+	return tmtypes.BlockWithResults{
+		Block: tmtypes.NewBlock(tmbasetypes.Header{Height: height}, tmtypes.NewData(stdTxs),
+			tmbasetypes.EvidenceData{}, tmbasetypes.Commit{}),
+		Results: tmtypes.BlockResults{
+			Height: height,
+			Results: tmtypes.ABCIResponses{
+				DeliverTx: deliverTxs,
+				EndBlock:  tmtypes.ResponseEndBlock{Events: endBlockEvents},
+			},
+		},
+	}
+}
+
+func TestMaintainer_ProcessBlock(t *testing.T) {
+	sender, err := sdk.AccAddressFromBech32(addr)
+	require.NoError(t, err)
+	oq := &orderQuery{books: map[string]ordertypes.BookRes{product: newBookRes()}}
+	maintainer := NewMaintainer(oq, nil, DefaultConfig())
+	require.NoError(t, maintainer.Add(product, product))
+	require.Equal(t, 1, oq.queries)
+	require.Error(t, maintainer.Add("eth-000_okt"))
+	require.Error(t, maintainer.Add(""))
+	require.Equal(t, []string{product}, maintainer.Products())
+	require.Error(t, maintainer.Run())
+
+	// block 1 with two orders placed, one of which is rejected, and a failed tx
+	orderItems := []ordertypes.OrderItem{
+		ordertypes.NewOrderItemDec(product, ordertypes.SideBuy, sdk.MustNewDecFromStr("10"), sdk.MustNewDecFromStr("5")),
+		ordertypes.NewOrderItemDec(product, ordertypes.SideSell, sdk.MustNewDecFromStr("11"), sdk.OneDec()),
+		ordertypes.NewOrderItemDec("eth-000_okt", ordertypes.SideSell, sdk.OneDec(), sdk.OneDec()),
+	}
+	newOrdersTx := sdk.NewStdTx([]sdk.Msg{ordertypes.NewMsgNewOrders(sender, orderItems)}, sdk.StdFee{}, nil, "")
+	require.NoError(t, maintainer.ProcessBlock(newBlockWithResults(1, []sdk.StdTx{newOrdersTx, newOrdersTx},
+		[]tmtypes.ResponseDeliverTx{
+			{Events: newOrdersEvents("new", `[{"code":0,"msg":"","orderid":"ID1-1"},`+
+				`{"code":1,"msg":"insufficient coins","orderid":""},{"code":0,"msg":"","orderid":"ID1-3"}]`)},
+			{Code: 1},
+		}, nil)))
+
+	book, ok := maintainer.Book(product)
+	require.True(t, ok)
+	require.Equal(t, int64(1), book.Height())
+	require.Equal(t, sdk.MustNewDecFromStr("5"), book.DepthAt(ordertypes.SideBuy, sdk.MustNewDecFromStr("10")))
+	require.True(t, book.DepthAt(ordertypes.SideSell, sdk.MustNewDecFromStr("11")).IsZero())
+
+	// block 2 with the cancellation of the new order and a fill of a seeded order
+	cancelTx := sdk.NewStdTx([]sdk.Msg{ordertypes.NewMsgCancelOrders(sender, []string{"ID1-1"})}, sdk.StdFee{},
+		nil, "")
+	require.NoError(t, maintainer.ProcessBlock(newBlockWithResults(2, []sdk.StdTx{cancelTx},
+		[]tmtypes.ResponseDeliverTx{
+			{Events: newOrdersEvents("cancel", `[{"code":0,"msg":"","orderid":"ID1-1"}]`)},
+		}, []tmtypes.Event{
+			newFillEvent("ID0-1", "SELL", "10.1", "1"),
+		})))
+	require.True(t, book.DepthAt(ordertypes.SideBuy, sdk.MustNewDecFromStr("10")).IsZero())
+	require.True(t, book.DepthAt(ordertypes.SideSell, sdk.MustNewDecFromStr("10.1")).IsZero())
+	require.False(t, book.IsStale())
+
+	require.NoError(t, maintainer.Resync(true))
+	require.Equal(t, 2, oq.queries)
+	require.NoError(t, maintainer.Resync(false))
+	require.Equal(t, 3, oq.queries)
+	require.Equal(t, sdk.OneDec(), book.DepthAt(ordertypes.SideSell, sdk.MustNewDecFromStr("10.1")))
+
+	// mismatched results turn the book stale
+	require.NoError(t, maintainer.ProcessBlock(newBlockWithResults(3, []sdk.StdTx{newOrdersTx},
+		[]tmtypes.ResponseDeliverTx{{}}, nil)))
+	require.True(t, book.IsStale())
+
+	require.Error(t, maintainer.ProcessBlock(newBlockWithResults(4, []sdk.StdTx{newOrdersTx}, nil, nil)))
+
+	// the cancellation of an order unknown to the books turns them stale
+	require.NoError(t, maintainer.Resync(true))
+	require.NoError(t, maintainer.ProcessBlock(newBlockWithResults(4, []sdk.StdTx{cancelTx},
+		[]tmtypes.ResponseDeliverTx{
+			{Events: newOrdersEvents("cancel", `[{"code":0,"msg":"","orderid":"ID0-2"}]`)},
+		}, nil)))
+	require.True(t, book.IsStale())
+
+	// the malformed events turn the books stale instead of failing the block
+	require.NoError(t, maintainer.Resync(true))
+	require.NoError(t, maintainer.ProcessBlock(newBlockWithResults(5, []sdk.StdTx{cancelTx},
+		[]tmtypes.ResponseDeliverTx{{Events: newOrdersEvents("cancel", `[{"code":"zero"}]`)}}, nil)))
+	require.True(t, book.IsStale())
+
+	require.NoError(t, maintainer.Resync(true))
+	require.NoError(t, maintainer.ProcessBlock(newBlockWithResults(6, nil, nil, []tmtypes.Event{
+		newFillEvent("ID0-1", "SELL", "ten", "1"),
+	})))
+	require.True(t, book.IsStale())
+	require.Equal(t, int64(6), book.Height())
+
+	maintainer.Remove(product)
+	_, ok = maintainer.Book(product)
+	require.False(t, ok)
+}
+
+func TestMaintainer_ExpireOrders(t *testing.T) {
+	sender, err := sdk.AccAddressFromBech32(addr)
+	require.NoError(t, err)
+	config := DefaultConfig()
+	config.OrderExpireBlocks = 2
+	maintainer := NewMaintainer(&orderQuery{books: map[string]ordertypes.BookRes{product: newBookRes()}}, nil, config)
+	require.NoError(t, maintainer.Add(product))
+
+	orderItems := []ordertypes.OrderItem{
+		ordertypes.NewOrderItemDec(product, ordertypes.SideBuy, sdk.MustNewDecFromStr("10"), sdk.MustNewDecFromStr("5")),
+	}
+	newOrdersTx := sdk.NewStdTx([]sdk.Msg{ordertypes.NewMsgNewOrders(sender, orderItems)}, sdk.StdFee{}, nil, "")
+	require.NoError(t, maintainer.ProcessBlock(newBlockWithResults(1, []sdk.StdTx{newOrdersTx},
+		[]tmtypes.ResponseDeliverTx{
+			{Events: newOrdersEvents("new", `[{"code":0,"msg":"","orderid":"ID1-1"}]`)},
+		}, nil)))
+	book, ok := maintainer.Book(product)
+	require.True(t, ok)
+
+	// the order rests until the end of the block on its expiry height
+	require.NoError(t, maintainer.ProcessBlock(newBlockWithResults(2, nil, nil, nil)))
+	require.Equal(t, sdk.MustNewDecFromStr("5"), book.DepthAt(ordertypes.SideBuy, sdk.MustNewDecFromStr("10")))
+	require.False(t, book.IsStale())
+
+	require.NoError(t, maintainer.ProcessBlock(newBlockWithResults(3, nil, nil, nil)))
+	require.True(t, book.DepthAt(ordertypes.SideBuy, sdk.MustNewDecFromStr("10")).IsZero())
+	require.False(t, book.IsStale())
+}
+
+type tendermintQuery struct {
+	exposed.TendermintQuery
+	heights []int64
+}
+
+func (tq *tendermintQuery) QueryLatestHeight() (int64, error) {
+	if len(tq.heights) == 0 {
+		return 0, errors.New("no height")
+	}
+
+	height := tq.heights[0]
+	tq.heights = tq.heights[1:]
+	return height, nil
+}
+
+func TestMaintainer_SnapshotHeight(t *testing.T) {
+	sender, err := sdk.AccAddressFromBech32(addr)
+	require.NoError(t, err)
+	oq := &orderQuery{books: map[string]ordertypes.BookRes{product: newBookRes()}}
+	// a new block is committed during the first snapshot
+	tq := &tendermintQuery{heights: []int64{5, 6, 6, 6}}
+	maintainer := NewMaintainer(oq, tq, DefaultConfig())
+	require.NoError(t, maintainer.Add(product))
+	require.Equal(t, 2, oq.queries)
+	book, ok := maintainer.Book(product)
+	require.True(t, ok)
+
+	// the order placed in the snapshot is tracked without changing the levels
+	orderItems := []ordertypes.OrderItem{
+		ordertypes.NewOrderItemDec(product, ordertypes.SideBuy, sdk.MustNewDecFromStr("9.9"), sdk.OneDec()),
+	}
+	newOrdersTx := sdk.NewStdTx([]sdk.Msg{ordertypes.NewMsgNewOrders(sender, orderItems)}, sdk.StdFee{}, nil, "")
+	require.NoError(t, maintainer.ProcessBlock(newBlockWithResults(6, []sdk.StdTx{newOrdersTx},
+		[]tmtypes.ResponseDeliverTx{
+			{Events: newOrdersEvents("new", `[{"code":0,"msg":"","orderid":"ID6-1"}]`)},
+		}, nil)))
+	require.Equal(t, sdk.OneDec(), book.DepthAt(ordertypes.SideBuy, sdk.MustNewDecFromStr("9.9")))
+
+	// the cancellation after the snapshot removes it from the levels
+	cancelTx := sdk.NewStdTx([]sdk.Msg{ordertypes.NewMsgCancelOrders(sender, []string{"ID6-1"})}, sdk.StdFee{},
+		nil, "")
+	require.NoError(t, maintainer.ProcessBlock(newBlockWithResults(7, []sdk.StdTx{cancelTx},
+		[]tmtypes.ResponseDeliverTx{
+			{Events: newOrdersEvents("cancel", `[{"code":0,"msg":"","orderid":"ID6-1"}]`)},
+		}, nil)))
+	require.True(t, book.DepthAt(ordertypes.SideBuy, sdk.MustNewDecFromStr("9.9")).IsZero())
+	require.False(t, book.IsStale())
+
+	// the latest height keeps changing
+	tq.heights = []int64{7, 8, 8, 9, 9, 10}
+	require.Error(t, maintainer.Resync(false))
+	// the latest height is unavailable
+	require.Error(t, maintainer.Resync(false))
+}
@@ -0,0 +1,45 @@
+package orderbook
+
+import (
+	"time"
+
+	sdk "github.com/okex/okchain-go-sdk/types"
+)
+
+// Level - structure of the total quantity of the orders on a price in the order book
+type Level struct {
+	Price    sdk.Dec `json:"price"`
+	Quantity sdk.Dec `json:"quantity"`
+}
+
+// Config - structure of the config for Maintainer
+type Config struct {
+	// StartHeight is the first height to follow. The height right after the latest one is used if it's non-positive
+	StartHeight int64
+	// ResyncBlocks is the number of blocks between two full resyncs from the depth book snapshots, which drops the
+	// changes without events such as the expiry. No periodic resync if it's non-positive
+	ResyncBlocks int64
+	// PollInterval is the interval to query the latest height while following the chain head
+	PollInterval time.Duration
+	// OrderExpireBlocks is the number of blocks that an order rests on the book before the expiry on chain. The orders
+	// placed after the seeding are dropped from the books once expired. It's loaded from the order params on chain in
+	// Run if non-positive
+	OrderExpireBlocks int64
+}
+
+// DefaultConfig returns the default config of Maintainer
+func DefaultConfig() Config {
+	return Config{
+		ResyncBlocks: 20,
+		PollInterval: time.Second,
+	}
+}
+
+// bookOrder is an order placed after the book is seeded, whose price is needed to remove it on cancellation or fill
+type bookOrder struct {
+	side   string
+	price  sdk.Dec
+	remain sdk.Dec
+	// height is the height that the order is placed on, which decides its expiry
+	height int64
+}
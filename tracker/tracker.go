@@ -0,0 +1,409 @@
+package tracker
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/okex/okchain-go-sdk/exposed"
+	ordertypes "github.com/okex/okchain-go-sdk/module/order/types"
+	"github.com/okex/okchain-go-sdk/module/tendermint"
+	tmtypes "github.com/okex/okchain-go-sdk/module/tendermint/types"
+	sdk "github.com/okex/okchain-go-sdk/types"
+	"github.com/okex/okchain-go-sdk/utils"
+)
+
+// Tracker follows the lifecycle of the orders through the fill and cancel events in blocks or by polling the order
+// details, and emits the status changes to the handler
+type Tracker struct {
+	oq      exposed.OrderQuery
+	tq      exposed.TendermintQuery
+	handler Handler
+	config  Config
+
+	mtx      sync.Mutex
+	orders   map[string]*OrderState
+	iterator *tendermint.BlockIterator
+	quit     chan struct{}
+	stopped  bool
+}
+
+// NewTracker creates a new instance of Tracker. Run follows the blocks if tq is not nil, or polls the order details
+// otherwise
+func NewTracker(oq exposed.OrderQuery, tq exposed.TendermintQuery, handler Handler, config Config) *Tracker {
+	return &Tracker{
+		oq:      oq,
+		tq:      tq,
+		handler: handler,
+		config:  config,
+		orders:  make(map[string]*OrderState),
+		quit:    make(chan struct{}),
+	}
+}
+
+// Track starts tracking the orders with their current details on chain
+func (t *Tracker) Track(orderIDs ...string) error {
+	for _, orderID := range orderIDs {
+		if _, ok := t.State(orderID); ok {
+			continue
+		}
+
+		orderDetail, err := t.oq.QueryOrderDetail(orderID)
+		if err != nil {
+			return err
+		}
+
+		// check again under the lock, so that the state advanced since the tracking by a concurrent call is kept
+		state := newOrderState(orderDetail)
+		t.mtx.Lock()
+		if _, ok := t.orders[orderID]; !ok {
+			t.orders[orderID] = &state
+		}
+		t.mtx.Unlock()
+	}
+
+	return nil
+}
+
+// TrackResponse starts tracking the orders placed in the tx response
+func (t *Tracker) TrackResponse(txResp sdk.TxResponse) error {
+	orderIDs := utils.GetOrderIDsFromResponse(&txResp)
+	if len(orderIDs) == 0 {
+		return errors.New("failed. no order placed in the tx response")
+	}
+
+	return t.Track(orderIDs...)
+}
+
+// Untrack stops tracking the orders
+func (t *Tracker) Untrack(orderIDs ...string) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	for _, orderID := range orderIDs {
+		delete(t.orders, orderID)
+	}
+}
+
+// State returns the latest known state of a tracked order
+func (t *Tracker) State(orderID string) (OrderState, bool) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	state, ok := t.orders[orderID]
+	if !ok {
+		return OrderState{}, false
+	}
+
+	return *state, true
+}
+
+// States returns the latest known states of all the tracked orders ordered by order ID
+func (t *Tracker) States() []OrderState {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	states := make([]OrderState, 0, len(t.orders))
+	for _, state := range t.orders {
+		states = append(states, *state)
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].OrderID < states[j].OrderID
+	})
+
+	return states
+}
+
+// OpenOrderIDs returns the IDs of the tracked orders that are still open
+func (t *Tracker) OpenOrderIDs() []string {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.openOrderIDs()
+}
+
+// Poll queries the details of the open orders and emits the changes since the last known states
+func (t *Tracker) Poll() error {
+	for _, orderID := range t.OpenOrderIDs() {
+		orderDetail, err := t.oq.QueryOrderDetail(orderID)
+		if err != nil {
+			return err
+		}
+
+		t.mtx.Lock()
+		var changes []StatusChange
+		if state, ok := t.orders[orderID]; ok {
+			changes = applyOrderDetail(state, orderDetail)
+		}
+		t.mtx.Unlock()
+		t.emit(changes)
+	}
+
+	return nil
+}
+
+// ProcessBlock applies the cancel and fill events of the tracked orders in the block and emits the changes. The
+// cancellations in txs are applied before the fills in the match at the end of the block. The events of the untracked
+// orders are filtered out before decoding, so that a malformed one of them doesn't fail the block
+func (t *Tracker) ProcessBlock(item tmtypes.BlockWithResults) error {
+	events := t.trackedEvents(utils.GetEventsFromBlockResults(item.Results))
+	cancelEvents, err := utils.ParseCancelOrderEvents(events)
+	if err != nil {
+		return err
+	}
+
+	fillEvents, err := utils.ParseFillEvents(events)
+	if err != nil {
+		return err
+	}
+
+	height := item.Block.Height
+	var changes []StatusChange
+	t.mtx.Lock()
+	for _, cancelEvent := range cancelEvents {
+		for _, result := range cancelEvent.Results {
+			if state, ok := t.orders[result.OrderID]; ok && result.Code == 0 && !state.Status.IsFinal() {
+				changes = append(changes, applyCancel(state, height))
+			}
+		}
+	}
+
+	for _, fillEvent := range fillEvents {
+		if state, ok := t.orders[fillEvent.OrderID]; ok && !state.Status.IsFinal() {
+			changes = append(changes, applyFill(state, fillEvent, height))
+		}
+	}
+	t.mtx.Unlock()
+
+	t.emit(changes)
+	return nil
+}
+
+// trackedEvents filters the events to the ones mentioning the tracked orders by the order ID attribute of the fills.
+// The message events are kept without the orders attributes that mention no tracked order, since the action and the
+// orders of a msg may be split into different message events
+func (t *Tracker) trackedEvents(events sdk.StringEvents) (tracked sdk.StringEvents) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	for _, event := range events {
+		if event.Type == ordertypes.EventTypeMessage {
+			msgEvent := sdk.StringEvent{Type: event.Type}
+			for _, attr := range event.Attributes {
+				if attr.Key != ordertypes.AttributeKeyOrders || t.mentionsOrders(attr.Value) {
+					msgEvent.Attributes = append(msgEvent.Attributes, attr)
+				}
+			}
+			tracked = append(tracked, msgEvent)
+			continue
+		}
+
+		for _, attr := range event.Attributes {
+			if attr.Key == ordertypes.AttributeKeyOrderID && t.orders[attr.Value] != nil {
+				tracked = append(tracked, event)
+				break
+			}
+		}
+	}
+
+	return
+}
+
+// mentionsOrders shows whether the raw json of the order results contains any tracked order ID
+func (t *Tracker) mentionsOrders(rawOrders string) bool {
+	for orderID := range t.orders {
+		if strings.Contains(rawOrders, strconv.Quote(orderID)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Run follows the blocks from the start height or polls the order details in the interval until Stop is called or an
+// error occurs
+func (t *Tracker) Run() error {
+	if t.tq != nil {
+		return t.runBlocks()
+	}
+
+	return t.runPolling()
+}
+
+// Stop stops the running tracker
+func (t *Tracker) Stop() {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if t.stopped {
+		return
+	}
+
+	t.stopped = true
+	close(t.quit)
+	if t.iterator != nil {
+		t.iterator.Close()
+	}
+}
+
+func (t *Tracker) runPolling() error {
+	pollInterval := t.config.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = DefaultConfig().PollInterval
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-t.quit:
+			return nil
+		case <-ticker.C:
+			if err := t.Poll(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (t *Tracker) runBlocks() error {
+	startHeight := t.config.StartHeight
+	if startHeight <= 0 {
+		latestHeight, err := t.tq.QueryLatestHeight()
+		if err != nil {
+			return err
+		}
+		startHeight = latestHeight
+	}
+
+	t.mtx.Lock()
+	if t.stopped {
+		t.mtx.Unlock()
+		return nil
+	}
+	iterator, err := tendermint.NewBlockIterator(t.tq, tendermint.NewBlockIteratorConfig(startHeight, 0, true))
+	if err != nil {
+		t.mtx.Unlock()
+		return err
+	}
+	t.iterator = iterator
+	t.mtx.Unlock()
+
+	for blocks := int64(1); iterator.Next(); blocks++ {
+		if err = t.ProcessBlock(iterator.Value()); err != nil {
+			iterator.Close()
+			return err
+		}
+
+		if t.config.ReconcileBlocks > 0 && blocks%t.config.ReconcileBlocks == 0 {
+			if err = t.Poll(); err != nil {
+				iterator.Close()
+				return err
+			}
+		}
+	}
+
+	return iterator.Err()
+}
+
+func (t *Tracker) openOrderIDs() (orderIDs []string) {
+	for orderID, state := range t.orders {
+		if !state.Status.IsFinal() {
+			orderIDs = append(orderIDs, orderID)
+		}
+	}
+	sort.Strings(orderIDs)
+
+	return
+}
+
+func (t *Tracker) emit(changes []StatusChange) {
+	for _, change := range changes {
+		t.handler.handle(change)
+	}
+}
+
+func newOrderState(orderDetail ordertypes.OrderDetail) OrderState {
+	return OrderState{
+		OrderID:        orderDetail.OrderID,
+		Product:        orderDetail.Product,
+		Side:           orderDetail.Side,
+		Price:          orderDetail.Price,
+		Quantity:       orderDetail.Quantity,
+		Status:         orderDetail.GetStatus(),
+		FilledAvgPrice: sdk.DecOrZero(orderDetail.FilledAvgPrice),
+		RemainQuantity: sdk.DecOrZero(orderDetail.RemainQuantity),
+	}
+}
+
+// applyOrderDetail updates the state with the polled order detail. The price of the fills since the last known state
+// is derived from the change of the filled average price
+func applyOrderDetail(state *OrderState, orderDetail ordertypes.OrderDetail) (changes []StatusChange) {
+	prevStatus, prevFilled, prevAvgPrice := state.Status, state.FilledQuantity(), state.FilledAvgPrice
+	*state = newOrderState(orderDetail)
+
+	if fillQuantity := state.FilledQuantity().Sub(prevFilled); fillQuantity.IsPositive() {
+		fillAmount := state.FilledAvgPrice.Mul(state.FilledQuantity()).Sub(prevAvgPrice.Mul(prevFilled))
+		kind := ChangeKindPartialFill
+		if state.Status == ordertypes.OrderStatusFilled {
+			kind = ChangeKindFill
+		}
+		changes = append(changes, newStatusChange(kind, prevStatus, *state, fillAmount.Quo(fillQuantity),
+			fillQuantity))
+		prevStatus = ordertypes.OrderStatusOpen
+	}
+
+	switch {
+	case state.Status.IsCancelled():
+		changes = append(changes, newStatusChange(ChangeKindCancel, prevStatus, *state, sdk.ZeroDec(), sdk.ZeroDec()))
+	case state.Status.IsExpired():
+		changes = append(changes, newStatusChange(ChangeKindExpire, prevStatus, *state, sdk.ZeroDec(), sdk.ZeroDec()))
+	}
+
+	return
+}
+
+func applyCancel(state *OrderState, height int64) StatusChange {
+	prevStatus := state.Status
+	state.Status = ordertypes.OrderStatusCancelled
+	if state.FilledQuantity().IsPositive() {
+		state.Status = ordertypes.OrderStatusPartialFilledCancelled
+	}
+	state.Height = height
+
+	return newStatusChange(ChangeKindCancel, prevStatus, *state, sdk.ZeroDec(), sdk.ZeroDec())
+}
+
+func applyFill(state *OrderState, fillEvent ordertypes.FillEvent, height int64) StatusChange {
+	prevStatus, prevFilled := state.Status, state.FilledQuantity()
+	fillQuantity := fillEvent.Quantity
+	if fillQuantity.GT(state.RemainQuantity) {
+		fillQuantity = state.RemainQuantity
+	}
+
+	state.RemainQuantity = state.RemainQuantity.Sub(fillQuantity)
+	if filled := state.FilledQuantity(); filled.IsPositive() {
+		state.FilledAvgPrice = state.FilledAvgPrice.Mul(prevFilled).Add(fillEvent.Price.Mul(fillQuantity)).Quo(filled)
+	}
+	state.Height = height
+
+	kind := ChangeKindPartialFill
+	if state.RemainQuantity.IsZero() {
+		kind = ChangeKindFill
+		state.Status = ordertypes.OrderStatusFilled
+	}
+
+	return newStatusChange(kind, prevStatus, *state, fillEvent.Price, fillQuantity)
+}
+
+func newStatusChange(kind ChangeKind, prevStatus ordertypes.OrderStatus, state OrderState, fillPrice,
+	fillQuantity sdk.Dec) StatusChange {
+	return StatusChange{
+		Kind:           kind,
+		OrderID:        state.OrderID,
+		PrevStatus:     prevStatus,
+		Status:         state.Status,
+		FillPrice:      fillPrice,
+		FillQuantity:   fillQuantity,
+		RemainQuantity: state.RemainQuantity,
+		Height:         state.Height,
+		State:          state,
+	}
+}
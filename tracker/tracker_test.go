@@ -0,0 +1,205 @@
+package tracker
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/okex/okchain-go-sdk/exposed"
+	ordertypes "github.com/okex/okchain-go-sdk/module/order/types"
+	tmtypes "github.com/okex/okchain-go-sdk/module/tendermint/types"
+	sdk "github.com/okex/okchain-go-sdk/types"
+	"github.com/stretchr/testify/require"
+	tmbasetypes "github.com/tendermint/tendermint/types"
+)
+
+const (
+	addr     = "okchain1dcsxvxgj374dv3wt9szflf9nz6342juzzkjnlz"
+	product  = "btc-000_okt"
+	orderID  = "ID0000000001-1"
+	orderID1 = "ID0000000001-2"
+)
+
+type orderQuery struct {
+	exposed.OrderQuery
+	details map[string]ordertypes.OrderDetail
+}
+
+func (oq orderQuery) QueryOrderDetail(orderID string) (ordertypes.OrderDetail, error) {
+	orderDetail, ok := oq.details[orderID]
+	if !ok {
+		return orderDetail, errors.New("order not found")
+	}
+
+	return orderDetail, nil
+}
+
+func newOrderDetail(orderID string, status ordertypes.OrderStatus, filledAvgPrice, remainQuantity string) ordertypes.
+	OrderDetail {
+	return ordertypes.OrderDetail{
+		OrderID:        orderID,
+		Product:        product,
+		Side:           "BUY",
+		Price:          sdk.MustNewDecFromStr("10"),
+		Quantity:       sdk.MustNewDecFromStr("4"),
+		Status:         int64(status),
+		FilledAvgPrice: sdk.MustNewDecFromStr(filledAvgPrice),
+		RemainQuantity: sdk.MustNewDecFromStr(remainQuantity),
+	}
+}
+
+func newEvent(eventType string, kvs ...string) tmtypes.Event {
+	event := tmtypes.Event{Type: eventType}
+	for i := 0; i < len(kvs); i += 2 {
+		event.Attributes = append(event.Attributes, tmtypes.KVPair{Key: []byte(kvs[i]), Value: []byte(kvs[i+1])})
+	}
+
+	return event
+}
+
+func newFillEvent(orderID, price, quantity string) tmtypes.Event {
+	return newEvent("fill", "order_id", orderID, "product", product, "side", "BUY", "price", price,
+		"quantity", quantity, "fee", "")
+}
+
+func newBlockWithResults(height int64, deliverTxs []tmtypes.ResponseDeliverTx, endBlockEvents []tmtypes.Event) tmtypes.
+	BlockWithResults {
+	return tmtypes.BlockWithResults{
+		Block: tmtypes.NewBlock(tmbasetypes.Header{Height: height}, tmtypes.NewData(nil),
+			tmbasetypes.EvidenceData{}, tmbasetypes.Commit{}),
+		Results: tmtypes.BlockResults{
+			Height: height,
+			Results: tmtypes.ABCIResponses{
+				DeliverTx: deliverTxs,
+				EndBlock:  tmtypes.ResponseEndBlock{Events: endBlockEvents},
+			},
+		},
+	}
+}
+
+func newRecorder() (*[]StatusChange, Handler) {
+	var changes []StatusChange
+	record := func(change StatusChange) {
+		changes = append(changes, change)
+	}
+
+	return &changes, Handler{OnPartialFill: record, OnFill: record, OnCancel: record, OnExpire: record}
+}
+
+func TestTracker_ProcessBlock(t *testing.T) {
+	oq := orderQuery{details: map[string]ordertypes.OrderDetail{
+		orderID:  newOrderDetail(orderID, ordertypes.OrderStatusOpen, "0", "4"),
+		orderID1: newOrderDetail(orderID1, ordertypes.OrderStatusOpen, "0", "4"),
+	}}
+	changes, handler := newRecorder()
+	tracker := NewTracker(oq, nil, handler, DefaultConfig())
+	require.NoError(t, tracker.Track(orderID, orderID1))
+	require.Error(t, tracker.Track("ID0000000001-3"))
+	require.Equal(t, []string{orderID, orderID1}, tracker.OpenOrderIDs())
+
+	// block 1 with a partial fill of each order and a fill of an untracked order
+	require.NoError(t, tracker.ProcessBlock(newBlockWithResults(1, nil, []tmtypes.Event{
+		newFillEvent(orderID, "10", "1"),
+		newFillEvent(orderID1, "9", "3"),
+		newFillEvent("ID0000000001-3", "9", "3"),
+	})))
+	require.Equal(t, 2, len(*changes))
+	require.Equal(t, ChangeKindPartialFill, (*changes)[0].Kind)
+	require.Equal(t, orderID, (*changes)[0].OrderID)
+	require.Equal(t, sdk.MustNewDecFromStr("10"), (*changes)[0].FillPrice)
+	require.Equal(t, sdk.MustNewDecFromStr("1"), (*changes)[0].FillQuantity)
+	require.Equal(t, sdk.MustNewDecFromStr("3"), (*changes)[0].RemainQuantity)
+	require.Equal(t, int64(1), (*changes)[0].Height)
+	require.Equal(t, ordertypes.OrderStatusOpen, (*changes)[0].Status)
+
+	// block 2 with the cancellation of the order and the rest fills of the other one
+	// the action and the orders of the msg are split into different message events in the raw abci events
+	cancelEvents := []tmtypes.Event{
+		newEvent("message", "action", "cancel"),
+		newEvent("message", "module", "order", "sender", addr),
+		newEvent("message", "orders", `[{"code":0,"msg":"","orderid":"`+orderID+`"}]`),
+	}
+	require.NoError(t, tracker.ProcessBlock(newBlockWithResults(2, []tmtypes.ResponseDeliverTx{
+		{Events: cancelEvents},
+	}, []tmtypes.Event{
+		newFillEvent(orderID, "10", "1"),
+		newFillEvent(orderID1, "8", "1"),
+	})))
+	require.Equal(t, 4, len(*changes))
+	require.Equal(t, ChangeKindCancel, (*changes)[2].Kind)
+	require.Equal(t, orderID, (*changes)[2].OrderID)
+	require.Equal(t, ordertypes.OrderStatusPartialFilledCancelled, (*changes)[2].Status)
+	require.Equal(t, ChangeKindFill, (*changes)[3].Kind)
+	require.Equal(t, orderID1, (*changes)[3].OrderID)
+	require.Equal(t, ordertypes.OrderStatusFilled, (*changes)[3].Status)
+	require.True(t, (*changes)[3].RemainQuantity.IsZero())
+	require.Equal(t, sdk.MustNewDecFromStr("8.75"), (*changes)[3].State.FilledAvgPrice)
+
+	state, ok := tracker.State(orderID)
+	require.True(t, ok)
+	require.Equal(t, sdk.MustNewDecFromStr("3"), state.RemainQuantity)
+	require.Equal(t, int64(2), state.Height)
+	require.Equal(t, 0, len(tracker.OpenOrderIDs()))
+
+	// a failed cancellation tx is skipped
+	require.NoError(t, tracker.ProcessBlock(newBlockWithResults(3, []tmtypes.ResponseDeliverTx{
+		{Code: 1, Events: cancelEvents},
+	}, nil)))
+	require.Equal(t, 4, len(*changes))
+
+	// bad fill event
+	require.Error(t, tracker.ProcessBlock(newBlockWithResults(4, nil, []tmtypes.Event{
+		newFillEvent(orderID, "ten", "1"),
+	})))
+
+	// bad events of the untracked orders are skipped
+	require.NoError(t, tracker.ProcessBlock(newBlockWithResults(5, []tmtypes.ResponseDeliverTx{
+		{Events: []tmtypes.Event{newEvent("message", "action", "cancel", "sender", "bad sender", "orders",
+			`[{"code":0,"msg":"","orderid":"ID0000000001-3"}]`)}},
+	}, []tmtypes.Event{
+		newFillEvent("ID0000000001-3", "ten", "1"),
+	})))
+	require.Equal(t, 4, len(*changes))
+
+	tracker.Untrack(orderID)
+	states := tracker.States()
+	require.Equal(t, 1, len(states))
+	require.Equal(t, orderID1, states[0].OrderID)
+}
+
+func TestTracker_Poll(t *testing.T) {
+	oq := orderQuery{details: map[string]ordertypes.OrderDetail{
+		orderID:  newOrderDetail(orderID, ordertypes.OrderStatusOpen, "0", "4"),
+		orderID1: newOrderDetail(orderID1, ordertypes.OrderStatusOpen, "10", "3"),
+	}}
+	changes, handler := newRecorder()
+	tracker := NewTracker(oq, nil, handler, DefaultConfig())
+	require.NoError(t, tracker.Track(orderID, orderID1))
+
+	// no change
+	require.NoError(t, tracker.Poll())
+	require.Equal(t, 0, len(*changes))
+
+	oq.details[orderID] = newOrderDetail(orderID, ordertypes.OrderStatusExpired, "0", "4")
+	oq.details[orderID1] = newOrderDetail(orderID1, ordertypes.OrderStatusPartialFilledCancelled, "8", "2")
+	require.NoError(t, tracker.Poll())
+	require.Equal(t, 3, len(*changes))
+	require.Equal(t, ChangeKindExpire, (*changes)[0].Kind)
+	require.Equal(t, orderID, (*changes)[0].OrderID)
+	require.Equal(t, ordertypes.OrderStatusOpen, (*changes)[0].PrevStatus)
+	require.Equal(t, ordertypes.OrderStatusExpired, (*changes)[0].Status)
+
+	// the fill price is derived from the average prices: (8*2 - 10*1) / 1
+	require.Equal(t, ChangeKindPartialFill, (*changes)[1].Kind)
+	require.Equal(t, orderID1, (*changes)[1].OrderID)
+	require.Equal(t, sdk.MustNewDecFromStr("6"), (*changes)[1].FillPrice)
+	require.Equal(t, sdk.MustNewDecFromStr("1"), (*changes)[1].FillQuantity)
+	require.Equal(t, ChangeKindCancel, (*changes)[2].Kind)
+	require.Equal(t, ordertypes.OrderStatusPartialFilledCancelled, (*changes)[2].Status)
+
+	// final orders are no longer polled
+	delete(oq.details, orderID)
+	require.NoError(t, tracker.Poll())
+	require.Equal(t, 3, len(*changes))
+
+	require.Error(t, tracker.TrackResponse(sdk.TxResponse{}))
+}
@@ -0,0 +1,115 @@
+package tracker
+
+import (
+	"fmt"
+	"time"
+
+	ordertypes "github.com/okex/okchain-go-sdk/module/order/types"
+	sdk "github.com/okex/okchain-go-sdk/types"
+)
+
+// ChangeKind shows the kind of a status change of an order
+type ChangeKind int
+
+// const of the change kinds
+const (
+	ChangeKindPartialFill ChangeKind = iota
+	ChangeKindFill
+	ChangeKindCancel
+	ChangeKindExpire
+)
+
+// String returns the name of the change kind
+func (ck ChangeKind) String() string {
+	switch ck {
+	case ChangeKindPartialFill:
+		return "PartialFill"
+	case ChangeKindFill:
+		return "Fill"
+	case ChangeKindCancel:
+		return "Cancel"
+	case ChangeKindExpire:
+		return "Expire"
+	default:
+		return fmt.Sprintf("Unknown(%d)", int(ck))
+	}
+}
+
+// OrderState - structure of the latest known state of a tracked order
+type OrderState struct {
+	OrderID        string                 `json:"order_id"`
+	Product        string                 `json:"product"`
+	Side           string                 `json:"side"`
+	Price          sdk.Dec                `json:"price"`
+	Quantity       sdk.Dec                `json:"quantity"`
+	Status         ordertypes.OrderStatus `json:"status"`
+	FilledAvgPrice sdk.Dec                `json:"filled_avg_price"`
+	RemainQuantity sdk.Dec                `json:"remain_quantity"`
+	// Height is the height of the block where the last change is found. It's 0 if the change is found by polling
+	Height int64 `json:"height"`
+}
+
+// FilledQuantity returns the quantity that has been filled
+func (os OrderState) FilledQuantity() sdk.Dec {
+	return os.Quantity.Sub(os.RemainQuantity)
+}
+
+// StatusChange - structure of a change of a tracked order
+type StatusChange struct {
+	Kind       ChangeKind             `json:"kind"`
+	OrderID    string                 `json:"order_id"`
+	PrevStatus ordertypes.OrderStatus `json:"prev_status"`
+	Status     ordertypes.OrderStatus `json:"status"`
+	// FillPrice and FillQuantity are the price and quantity of the fill. They are zero for a cancellation or an expiry
+	FillPrice      sdk.Dec `json:"fill_price"`
+	FillQuantity   sdk.Dec `json:"fill_quantity"`
+	RemainQuantity sdk.Dec `json:"remain_quantity"`
+	Height         int64   `json:"height"`
+	// State is the state of the order after the change
+	State OrderState `json:"state"`
+}
+
+// Handler - structure of the callbacks on the status changes of the tracked orders. A nil callback is skipped
+type Handler struct {
+	OnPartialFill func(change StatusChange)
+	OnFill        func(change StatusChange)
+	OnCancel      func(change StatusChange)
+	OnExpire      func(change StatusChange)
+}
+
+func (h Handler) handle(change StatusChange) {
+	var callback func(StatusChange)
+	switch change.Kind {
+	case ChangeKindPartialFill:
+		callback = h.OnPartialFill
+	case ChangeKindFill:
+		callback = h.OnFill
+	case ChangeKindCancel:
+		callback = h.OnCancel
+	case ChangeKindExpire:
+		callback = h.OnExpire
+	}
+
+	if callback != nil {
+		callback(change)
+	}
+}
+
+// Config - structure of the config for Tracker
+type Config struct {
+	// PollInterval is the interval to query the order details in polling mode
+	PollInterval time.Duration
+	// StartHeight is the first height to follow in block mode. The latest height is used if it's non-positive
+	StartHeight int64
+	// ReconcileBlocks is the number of blocks between two rounds of polling in block mode, which catches up the changes
+	// without events such as the expiry. No polling in block mode if it's non-positive
+	ReconcileBlocks int64
+}
+
+// DefaultConfig returns the default config of Tracker
+func DefaultConfig() Config {
+	return Config{
+		PollInterval:    3 * time.Second,
+		ReconcileBlocks: 10,
+	}
+}
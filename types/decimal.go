@@ -169,6 +169,14 @@ func MustNewDecFromStr(s string) Dec {
 	return dec
 }
 
+// DecOrZero returns the decimal, or zero if it's nil such as a field missing in the decoded json
+func DecOrZero(d Dec) Dec {
+	if d.IsNil() {
+		return ZeroDec()
+	}
+	return d
+}
+
 //nolint
 func (d Dec) IsNil() bool       { return d.Int == nil }                 // is decimal nil
 func (d Dec) IsZero() bool      { return (d.Int).Sign() == 0 }          // is equal to zero
@@ -135,6 +135,11 @@ func (i Int) IsInt64() bool {
 	return i.i.IsInt64()
 }
 
+// IsNil returns true if Int is uninitialized, such as a field missing in the decoded json
+func (i Int) IsNil() bool {
+	return i.i == nil
+}
+
 // IsZero returns true if Int is zero
 func (i Int) IsZero() bool {
 	return i.i.Sign() == 0
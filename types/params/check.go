@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"strings"
 
+	ordertypes "github.com/okex/okchain-go-sdk/module/order/types"
 	tokentypes "github.com/okex/okchain-go-sdk/module/token/types"
+	sdk "github.com/okex/okchain-go-sdk/types"
 	"github.com/okex/okchain-go-sdk/types/crypto/keys"
 )
 
@@ -13,7 +15,9 @@ const (
 	tokenDescLenLimit = 256
 	countDefault      = 10
 	perPageDefault    = 50
-	perPageMax        = 200
+
+	// PerPageMax is the max number of items in a page of the list queries
+	PerPageMax = 200
 )
 
 // CheckProductParams gives a quick validity check for the input product params
@@ -55,6 +59,83 @@ func CheckQueryTokenInfoParams(ownerAddr, symbol string) error {
 	return nil
 }
 
+// CheckQueryTokensParams gives a quick validity check for the input params of query tokens
+func CheckQueryTokensParams(page, perPage int) error {
+	if page <= 0 {
+		return fmt.Errorf("failed. invalid page: %d", page)
+	}
+
+	if perPage <= 0 || perPage > PerPageMax {
+		return fmt.Errorf("failed. invalid per-page: %d", perPage)
+	}
+
+	return nil
+}
+
+// CheckTokenMintParams gives a quick validity check for the input params of token minting
+func CheckTokenMintParams(fromInfo keys.Info, passWd, symbol string, amount int64) error {
+	if err := CheckKeyParams(fromInfo, passWd); err != nil {
+		return err
+	}
+
+	if len(symbol) == 0 {
+		return errors.New("failed. empty token symbol")
+	}
+
+	if amount <= 0 {
+		return errors.New("failed. amount to mint must be greater than 0")
+	}
+
+	return nil
+}
+
+// CheckTokenBurnParams gives a quick validity check for the input params of token burning and returns the coin parsed
+func CheckTokenBurnParams(fromInfo keys.Info, passWd, coinStr string) (coin sdk.DecCoin, err error) {
+	if err = CheckKeyParams(fromInfo, passWd); err != nil {
+		return
+	}
+
+	if len(coinStr) == 0 {
+		return coin, errors.New("failed. empty coin to burn")
+	}
+
+	if coin, err = sdk.ParseDecCoin(coinStr); err != nil {
+		return coin, fmt.Errorf("failed. parse DecCoin [%s] error: %s", coinStr, err)
+	}
+
+	if !coin.Amount.IsPositive() {
+		return coin, errors.New("failed. amount to burn must be greater than 0")
+	}
+
+	return
+}
+
+// CheckTokenEditParams gives a quick validity check for the input params of token editing
+func CheckTokenEditParams(fromInfo keys.Info, passWd, symbol, tokenDesc, wholeName string, isDescEdit,
+	isWholeNameEdit bool) error {
+	if err := CheckKeyParams(fromInfo, passWd); err != nil {
+		return err
+	}
+
+	if len(symbol) == 0 {
+		return errors.New("failed. empty token symbol")
+	}
+
+	if !isDescEdit && !isWholeNameEdit {
+		return errors.New("failed. nothing to edit")
+	}
+
+	if isDescEdit && len(tokenDesc) > tokenDescLenLimit {
+		return errors.New("failed. invalid token description")
+	}
+
+	if isWholeNameEdit && len(wholeName) == 0 {
+		return errors.New("failed. empty whole name")
+	}
+
+	return nil
+}
+
 // CheckTokenIssueParams gives a quick validity check for the input params of token issuing
 func CheckTokenIssueParams(fromInfo keys.Info, passWd, orgSymbol, wholeName, tokenDesc string) error {
 	if err := CheckKeyParams(fromInfo, passWd); err != nil {
@@ -97,6 +178,24 @@ func CheckTransferUnitsParams(fromInfo keys.Info, passWd string, transfers []tok
 	return nil
 }
 
+// CheckBatchPayoutParams gives a quick validity check for the input params of batch payout
+func CheckBatchPayoutParams(fromInfo keys.Info, passWd string, records []tokentypes.PayoutRecord,
+	config tokentypes.PayoutConfig) error {
+	if err := CheckKeyParams(fromInfo, passWd); err != nil {
+		return err
+	}
+
+	if len(records) == 0 {
+		return errors.New("failed. no payout input")
+	}
+
+	if config.MaxTransfersPerTx < 0 || config.MaxMsgBytes < 0 {
+		return errors.New("failed. the limits of payout config are not allowed to be negative")
+	}
+
+	return nil
+}
+
 // CheckVoteParams gives a quick validity check for the input params of multi-voting
 func CheckVoteParams(fromInfo keys.Info, passWd string, valAddrs []string) error {
 	if err := CheckKeyParams(fromInfo, passWd); err != nil {
@@ -174,6 +273,25 @@ func CheckNewOrderParams(fromInfo keys.Info, passWd string, products, sides, pri
 	return nil
 }
 
+// CheckOrderItemsParams gives a quick validity check for the input order items for placing orders
+func CheckOrderItemsParams(fromInfo keys.Info, passWd string, orderItems []ordertypes.OrderItem) error {
+	if err := CheckKeyParams(fromInfo, passWd); err != nil {
+		return err
+	}
+
+	if len(orderItems) == 0 {
+		return errors.New("failed. no order item input")
+	}
+
+	for _, orderItem := range orderItems {
+		if err := orderItem.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // CheckCancelOrderParams gives a quick validity check for the input params for cancelling orders
 func CheckCancelOrderParams(fromInfo keys.Info, passWd string, orderIDs []string) error {
 	if err := CheckKeyParams(fromInfo, passWd); err != nil {
@@ -193,6 +311,28 @@ func CheckCancelOrderParams(fromInfo keys.Info, passWd string, orderIDs []string
 	return nil
 }
 
+// CheckReplaceOrdersParams gives a quick validity check for the input params for replacing orders
+func CheckReplaceOrdersParams(fromInfo keys.Info, passWd string, orderIDs []string,
+	orderItems []ordertypes.OrderItem) error {
+	if len(orderIDs) == 0 {
+		return errors.New("failed. empty orderIDs input")
+	}
+
+	if len(orderIDs) != len(orderItems) {
+		return fmt.Errorf("failed. %d orderIDs but %d order items", len(orderIDs), len(orderItems))
+	}
+
+	if len(orderIDs) > ordertypes.MaxOrdersPerMsg {
+		return fmt.Errorf("failed. no more than %d orders can be replaced in a tx", ordertypes.MaxOrdersPerMsg)
+	}
+
+	if err := CheckCancelOrderParams(fromInfo, passWd, orderIDs); err != nil {
+		return err
+	}
+
+	return CheckOrderItemsParams(fromInfo, passWd, orderItems)
+}
+
 // CheckQueryOrderDetailParams gives a quick validity check for the input params of query order detail
 func CheckQueryOrderDetailParams(orderID string) error {
 	if len(orderID) == 0 {
@@ -296,8 +436,8 @@ func checkParamsPaging(start, end, page, perPage int) (perPageRet int, err error
 
 	if perPage == 0 {
 		perPageRet = perPageDefault
-	} else if perPage > perPageMax {
-		perPageRet = perPageMax
+	} else if perPage > PerPageMax {
+		perPageRet = PerPageMax
 	} else {
 		perPageRet = perPage
 	}
@@ -1,41 +1,74 @@
 package utils
 
 import (
-	"bytes"
 	"encoding/json"
 
 	bkdtypes "github.com/okex/okchain-go-sdk/module/backend/types"
 )
 
-// UnmarshalListResponse unmarshals the list response from data bytes
-func UnmarshalListResponse(bz []byte, ptr interface{}) error {
-	var lr bkdtypes.ListResponse
-	if err := json.Unmarshal(bz, &lr); err != nil {
-		return err
+// rawBaseResponse - structure of the base response envelope with the data kept raw
+type rawBaseResponse struct {
+	bkdtypes.ResponseError
+	Data json.RawMessage `json:"data"`
+}
+
+// rawListData - structure of the data in the list response envelope with the list kept raw
+type rawListData struct {
+	Data      json.RawMessage    `json:"data"`
+	ParamPage bkdtypes.ParamPage `json:"param_page"`
+}
+
+// UnmarshalListResponse unmarshals the list response from data bytes and returns its pagination info
+// NOTE: a non-zero code in the response is returned as backend types.ResponseError
+func UnmarshalListResponse(bz []byte, ptr interface{}) (paramPage bkdtypes.ParamPage, err error) {
+	// the code is checked before the data, which may be in any shape with a non-zero code
+	var br rawBaseResponse
+	if err = json.Unmarshal(bz, &br); err != nil {
+		return
 	}
 
-	jsonBytes, err := json.Marshal(lr.Data.Data)
-	if err != nil {
-		return err
+	if br.Code != 0 {
+		return paramPage, br.ResponseError
 	}
 
-	if err := json.Unmarshal(jsonBytes, ptr); err != nil {
-		return err
+	var ld rawListData
+	if err = unmarshalRawData(br.Data, &ld); err != nil {
+		return
+	}
+
+	if err = unmarshalRawData(ld.Data, ptr); err != nil {
+		return
 	}
-	return nil
+
+	return ld.ParamPage, nil
 }
 
 // GetDataFromBaseResponse gets the detail data from the base response bytes
-func GetDataFromBaseResponse(bytes []byte, ptr interface{}) error {
-	dataBytes := getRawDataFromBaseResponse(bytes)
-	if err := json.Unmarshal(dataBytes, ptr); err != nil {
+// NOTE: a non-zero code in the response is returned as backend types.ResponseError
+func GetDataFromBaseResponse(bz []byte, ptr interface{}) error {
+	var br rawBaseResponse
+	if err := json.Unmarshal(bz, &br); err != nil {
 		return err
 	}
-	return nil
+
+	if br.Code != 0 {
+		return br.ResponseError
+	}
+
+	return unmarshalRawData(br.Data, ptr)
 }
 
-func getRawDataFromBaseResponse(bz []byte) []byte {
-	preIndex := bytes.Index(bz, []byte("data"))
-	//sufIndex := bytes.LastIndex(bz, []byte("detail_msg"))
-	return bz[preIndex+6 : len(bz)-1]
+// IsBackendResponseError shows whether the error is the one that backend responds with a non-zero code
+func IsBackendResponseError(err error) bool {
+	_, ok := err.(bkdtypes.ResponseError)
+	return ok
+}
+
+func unmarshalRawData(data json.RawMessage, ptr interface{}) error {
+	// leave the target empty with the null data
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+
+	return json.Unmarshal(data, ptr)
 }
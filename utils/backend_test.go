@@ -0,0 +1,69 @@
+package utils
+
+import (
+	bkdtypes "github.com/okex/okchain-go-sdk/module/backend/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestGetDataFromBaseResponse(t *testing.T) {
+	// "data" in the msg doesn't mislead the decoding
+	bz := []byte(`{"code":0,"msg":"data is ready","detail_msg":"no data missing","data":[["1.024","2.048"]]}`)
+	var candles [][]string
+	require.NoError(t, GetDataFromBaseResponse(bz, &candles))
+	require.Equal(t, [][]string{{"1.024", "2.048"}}, candles)
+
+	// null data
+	candles = nil
+	require.NoError(t, GetDataFromBaseResponse([]byte(`{"code":0,"msg":"","detail_msg":"","data":null}`), &candles))
+	require.Nil(t, candles)
+
+	// non-zero code
+	bz = []byte(`{"code":30001,"msg":"invalid params","detail_msg":"product is not found","data":null}`)
+	err := GetDataFromBaseResponse(bz, &candles)
+	require.Error(t, err)
+	require.True(t, IsBackendResponseError(err))
+	respErr := err.(bkdtypes.ResponseError)
+	require.Equal(t, 30001, respErr.Code)
+	require.Equal(t, "invalid params", respErr.Msg)
+	require.Equal(t, "product is not found", respErr.DetailMsg)
+
+	// bad bytes
+	err = GetDataFromBaseResponse([]byte("bad bytes"), &candles)
+	require.Error(t, err)
+	require.False(t, IsBackendResponseError(err))
+	require.Error(t, GetDataFromBaseResponse([]byte(`{"code":0,"data":{"key":"value"}}`), &candles))
+}
+
+func TestUnmarshalListResponse(t *testing.T) {
+	bz := []byte(`{"code":0,"msg":"data","detail_msg":"data","data":{"data":[{"order_id":"ID0000000000-1"},` +
+		`{"order_id":"ID0000000000-2"}],"param_page":{"page":1,"per_page":2,"total":5}}}`)
+	var orders []bkdtypes.Order
+	paramPage, err := UnmarshalListResponse(bz, &orders)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(orders))
+	require.Equal(t, "ID0000000000-2", orders[1].OrderID)
+	require.Equal(t, bkdtypes.ParamPage{Page: 1, PerPage: 2, Total: 5}, paramPage)
+	require.Equal(t, 3, paramPage.TotalPages())
+	require.True(t, paramPage.HasMore())
+	paramPage.Page = 3
+	require.False(t, paramPage.HasMore())
+
+	// non-zero code
+	bz = []byte(`{"code":30001,"msg":"invalid params","detail_msg":"","data":{"data":null}}`)
+	_, err = UnmarshalListResponse(bz, &orders)
+	require.True(t, IsBackendResponseError(err))
+	require.Equal(t, "failed. backend responds with code 30001: invalid params", err.Error())
+
+	// the message of the error envelope is returned whatever the data is
+	bz = []byte(`{"code":30002,"msg":"address is invalid","detail_msg":"","data":"okchain1"}`)
+	_, err = UnmarshalListResponse(bz, &orders)
+	require.True(t, IsBackendResponseError(err))
+	require.Equal(t, 30002, err.(bkdtypes.ResponseError).Code)
+
+	_, err = UnmarshalListResponse([]byte(`{"code":0,"data":[]}`), &orders)
+	require.Error(t, err)
+
+	_, err = UnmarshalListResponse([]byte("bad bytes"), &orders)
+	require.Error(t, err)
+}
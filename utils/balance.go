@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	bkdtypes "github.com/okex/okchain-go-sdk/module/backend/types"
+	sdk "github.com/okex/okchain-go-sdk/types"
+)
+
+// GetOpenOrdersLockedCoins computes the coins locked by the open orders. A BUY order locks the quote asset of the
+// price times the remaining quantity, while a SELL order locks the base asset of the remaining quantity
+func GetOpenOrdersLockedCoins(orders []bkdtypes.Order) (lockedCoins sdk.DecCoins, err error) {
+	lockedCoins = sdk.DecCoins{}
+	for _, order := range orders {
+		assets := strings.Split(order.Product, "_")
+		if len(assets) != 2 {
+			return nil, fmt.Errorf("failed. invalid product %s of order %s", order.Product, order.OrderID)
+		}
+
+		quantityStr := order.RemainQuantity
+		if len(quantityStr) == 0 {
+			quantityStr = order.Quantity
+		}
+		quantity, err := sdk.NewDecFromStr(quantityStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed. parse quantity %s of order %s error: %s", quantityStr, order.OrderID, err)
+		}
+
+		var locked sdk.DecCoin
+		switch order.Side {
+		case "BUY":
+			price, err := sdk.NewDecFromStr(order.Price)
+			if err != nil {
+				return nil, fmt.Errorf("failed. parse price %s of order %s error: %s", order.Price, order.OrderID, err)
+			}
+			locked = sdk.DecCoin{Denom: assets[1], Amount: price.Mul(quantity)}
+		case "SELL":
+			locked = sdk.DecCoin{Denom: assets[0], Amount: quantity}
+		default:
+			return nil, fmt.Errorf("failed. invalid side %s of order %s", order.Side, order.OrderID)
+		}
+
+		if locked.IsZero() {
+			continue
+		}
+
+		if !(sdk.DecCoins{locked}).IsValid() {
+			return nil, fmt.Errorf("failed. invalid locked coin %s%s of order %s", locked.Amount, locked.Denom, order.OrderID)
+		}
+		lockedCoins = lockedCoins.Add(sdk.DecCoins{locked})
+	}
+
+	return
+}
@@ -0,0 +1,89 @@
+package utils
+
+import (
+	"testing"
+
+	bkdtypes "github.com/okex/okchain-go-sdk/module/backend/types"
+	"github.com/okex/okchain-go-sdk/module/token/types"
+	sdk "github.com/okex/okchain-go-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetOpenOrdersLockedCoins(t *testing.T) {
+	orders := []bkdtypes.Order{
+		{OrderID: "ID1", Product: "btc-000_okt", Side: "BUY", Price: "10.5", Quantity: "3", RemainQuantity: "2"},
+		{OrderID: "ID2", Product: "btc-000_okt", Side: "SELL", Price: "11", Quantity: "1.5"},
+		{OrderID: "ID3", Product: "eth-000_okt", Side: "BUY", Price: "2", Quantity: "1", RemainQuantity: "1"},
+		{OrderID: "ID4", Product: "eth-000_okt", Side: "SELL", Price: "2", Quantity: "1", RemainQuantity: "0"},
+	}
+
+	lockedCoins, err := GetOpenOrdersLockedCoins(orders)
+	require.NoError(t, err)
+	expectedCoins, err := sdk.ParseDecCoins("1.5btc-000,23okt")
+	require.NoError(t, err)
+	require.Equal(t, expectedCoins, lockedCoins)
+
+	lockedCoins, err = GetOpenOrdersLockedCoins(nil)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(lockedCoins))
+
+	badOrders := []bkdtypes.Order{
+		{OrderID: "ID1", Product: "btc-000", Side: "BUY", Price: "1", Quantity: "1"},
+		{OrderID: "ID1", Product: "btc-000_okt", Side: "buy", Price: "1", Quantity: "1"},
+		{OrderID: "ID1", Product: "btc-000_okt", Side: "BUY", Price: "1,0", Quantity: "1"},
+		{OrderID: "ID1", Product: "btc-000_okt", Side: "SELL", Price: "1", Quantity: "one"},
+		{OrderID: "ID1", Product: "btc-000_okt", Side: "SELL", Price: "1", Quantity: "-1"},
+		{OrderID: "ID1", Product: "BTC_okt", Side: "SELL", Price: "1", Quantity: "1"},
+	}
+	for _, badOrder := range badOrders {
+		_, err = GetOpenOrdersLockedCoins([]bkdtypes.Order{badOrder})
+		require.Error(t, err)
+	}
+}
+
+func TestNewBalanceSheet(t *testing.T) {
+	accTokensInfo := types.AccountTokensInfo{
+		Address: "okchain1dcsxvxgj374dv3wt9szflf9nz6342juzzkjnlz",
+		Currencies: []types.CoinInfo{
+			{Symbol: "okt", Available: "100", Freeze: "1", Locked: "23"},
+			{Symbol: "btc-000", Available: "10", Freeze: "", Locked: "1"},
+		},
+	}
+	accTokensInfoDec, err := accTokensInfo.ToDec()
+	require.NoError(t, err)
+	require.Equal(t, "124.00000000", accTokensInfoDec.Currencies[0].Total.String())
+	require.Equal(t, "0.00000000", accTokensInfoDec.Currencies[1].Freeze.String())
+	require.Equal(t, "11.00000000", accTokensInfoDec.Currencies[1].Total.String())
+
+	accCoins, err := sdk.ParseDecCoins("10btc-000,5eth-000,99okt")
+	require.NoError(t, err)
+	orderLocked, err := sdk.ParseDecCoins("1.5btc-000,23okt")
+	require.NoError(t, err)
+
+	balanceSheet := types.NewBalanceSheet(accTokensInfoDec, accCoins, orderLocked)
+	require.Equal(t, accTokensInfo.Address, balanceSheet.Address)
+	require.Equal(t, 3, len(balanceSheet.Entries))
+	require.Equal(t, "btc-000", balanceSheet.Entries[0].Denom)
+	require.Equal(t, "eth-000", balanceSheet.Entries[1].Denom)
+	require.Equal(t, "okt", balanceSheet.Entries[2].Denom)
+
+	// the available amount comes from the account coins
+	okt, ok := balanceSheet.Get("okt")
+	require.True(t, ok)
+	require.Equal(t, "99.00000000", okt.Available.String())
+	require.Equal(t, "123.00000000", okt.Total.String())
+	require.True(t, okt.IsConsistent())
+
+	eth, ok := balanceSheet.Get("eth-000")
+	require.True(t, ok)
+	require.Equal(t, "5.00000000", eth.Total.String())
+
+	_, ok = balanceSheet.Get("xxb-000")
+	require.False(t, ok)
+
+	require.Equal(t, []string{"btc-000"}, balanceSheet.InconsistentDenoms())
+
+	accTokensInfo.Currencies[0].Locked = "2.3.4"
+	_, err = accTokensInfo.ToDec()
+	require.Error(t, err)
+}
@@ -0,0 +1,353 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	dex "github.com/okex/okchain-go-sdk/module/dex/types"
+	order "github.com/okex/okchain-go-sdk/module/order/types"
+	staking "github.com/okex/okchain-go-sdk/module/staking/types"
+	"github.com/okex/okchain-go-sdk/module/tendermint/types"
+	token "github.com/okex/okchain-go-sdk/module/token/types"
+	sdk "github.com/okex/okchain-go-sdk/types"
+	"time"
+)
+
+// GetEventsFromResponse collects the events of all msgs from the tx response of broadcasting
+func GetEventsFromResponse(txResp *sdk.TxResponse) (events sdk.StringEvents) {
+	for _, msgLog := range txResp.Logs {
+		events = append(events, msgLog.Events...)
+	}
+
+	if len(events) == 0 {
+		return txResp.Events
+	}
+
+	return
+}
+
+// GetEventsFromDeliverTx converts the events in the deliver tx response of block results to string events
+func GetEventsFromDeliverTx(deliverTx types.ResponseDeliverTx) sdk.StringEvents {
+	return GetEventsFromABCIEvents(deliverTx.Events)
+}
+
+// GetEventsFromBlockResults converts the events of the begin block, the successful txs and the end block in block
+// results to string events in the order of execution
+func GetEventsFromBlockResults(blockResults types.BlockResults) (events sdk.StringEvents) {
+	events = append(events, GetEventsFromABCIEvents(blockResults.Results.BeginBlock.Events)...)
+	for _, deliverTx := range blockResults.Results.DeliverTx {
+		if deliverTx.Code == 0 {
+			events = append(events, GetEventsFromABCIEvents(deliverTx.Events)...)
+		}
+	}
+
+	return append(events, GetEventsFromABCIEvents(blockResults.Results.EndBlock.Events)...)
+}
+
+// GetEventsFromABCIEvents converts the abci events to string events
+func GetEventsFromABCIEvents(abciEvents []types.Event) sdk.StringEvents {
+	events := make(sdk.StringEvents, len(abciEvents))
+	for i, event := range abciEvents {
+		events[i].Type = event.Type
+		for _, kvPair := range event.Attributes {
+			events[i].Attributes = append(events[i].Attributes, sdk.Attribute{
+				Key:   string(kvPair.Key),
+				Value: string(kvPair.Value),
+			})
+		}
+	}
+
+	return events
+}
+
+// ParseTransferEvents decodes the transfer events of token module
+func ParseTransferEvents(events sdk.StringEvents) (transferEvents []token.TransferEvent, err error) {
+	for _, attrs := range groupAttributes(events, token.EventTypeTransfer) {
+		var event token.TransferEvent
+		if event.Sender, err = attrs.accAddress(token.AttributeKeySender); err != nil {
+			return nil, err
+		}
+		if event.Recipient, err = attrs.accAddress(token.AttributeKeyRecipient); err != nil {
+			return nil, err
+		}
+		if event.Amount, err = attrs.decCoins(token.AttributeKeyAmount); err != nil {
+			return nil, err
+		}
+		transferEvents = append(transferEvents, event)
+	}
+
+	return
+}
+
+// ParseNewOrderEvents decodes the events of placing orders
+func ParseNewOrderEvents(events sdk.StringEvents) ([]order.OrdersEvent, error) {
+	return parseOrdersEvents(events, order.ActionNewOrders)
+}
+
+// ParseCancelOrderEvents decodes the events of cancelling orders
+func ParseCancelOrderEvents(events sdk.StringEvents) ([]order.OrdersEvent, error) {
+	return parseOrdersEvents(events, order.ActionCancelOrders)
+}
+
+// ParseFillEvents decodes the fill events of order module in the match
+func ParseFillEvents(events sdk.StringEvents) (fillEvents []order.FillEvent, err error) {
+	for _, attrs := range groupAttributes(events, order.EventTypeFill) {
+		var event order.FillEvent
+		if event.OrderID, err = attrs.str(order.AttributeKeyOrderID); err != nil {
+			return nil, err
+		}
+		if event.Product, err = attrs.str(order.AttributeKeyProduct); err != nil {
+			return nil, err
+		}
+		if event.Side, err = attrs.str(order.AttributeKeySide); err != nil {
+			return nil, err
+		}
+		if event.Price, err = attrs.dec(order.AttributeKeyPrice); err != nil {
+			return nil, err
+		}
+		if event.Quantity, err = attrs.dec(order.AttributeKeyQuantity); err != nil {
+			return nil, err
+		}
+		if event.Fee, err = attrs.decCoins(order.AttributeKeyFee); err != nil {
+			return nil, err
+		}
+		fillEvents = append(fillEvents, event)
+	}
+
+	return
+}
+
+// ParseDelegateEvents decodes the delegate events of staking module
+func ParseDelegateEvents(events sdk.StringEvents) (delegateEvents []staking.DelegateEvent, err error) {
+	for _, attrs := range groupAttributes(events, staking.EventTypeDelegate) {
+		var event staking.DelegateEvent
+		if event.Delegator, err = attrs.accAddress(staking.AttributeKeyDelegator); err != nil {
+			return nil, err
+		}
+		if event.Amount, err = attrs.decCoin(staking.AttributeKeyAmount); err != nil {
+			return nil, err
+		}
+		delegateEvents = append(delegateEvents, event)
+	}
+
+	return
+}
+
+// ParseUnbondEvents decodes the unbond events of staking module
+func ParseUnbondEvents(events sdk.StringEvents) (unbondEvents []staking.UnbondEvent, err error) {
+	for _, attrs := range groupAttributes(events, staking.EventTypeUnbond) {
+		var event staking.UnbondEvent
+		if event.Delegator, err = attrs.accAddress(staking.AttributeKeyDelegator); err != nil {
+			return nil, err
+		}
+		if event.Amount, err = attrs.decCoin(staking.AttributeKeyAmount); err != nil {
+			return nil, err
+		}
+		if event.CompletionTime, err = attrs.time(staking.AttributeKeyCompletionTime); err != nil {
+			return nil, err
+		}
+		unbondEvents = append(unbondEvents, event)
+	}
+
+	return
+}
+
+// ParseListEvents decodes the list events of dex module
+func ParseListEvents(events sdk.StringEvents) (listEvents []dex.ListEvent, err error) {
+	for _, attrs := range groupAttributes(events, dex.EventTypeList) {
+		var event dex.ListEvent
+		if event.Owner, err = attrs.accAddress(dex.AttributeKeyOwner); err != nil {
+			return nil, err
+		}
+		if event.BaseAsset, err = attrs.str(dex.AttributeKeyBaseAsset); err != nil {
+			return nil, err
+		}
+		if event.QuoteAsset, err = attrs.str(dex.AttributeKeyQuoteAsset); err != nil {
+			return nil, err
+		}
+		if event.InitPrice, err = attrs.dec(dex.AttributeKeyInitPrice); err != nil {
+			return nil, err
+		}
+		listEvents = append(listEvents, event)
+	}
+
+	return
+}
+
+// ParseDepositEvents decodes the deposit events of dex module
+func ParseDepositEvents(events sdk.StringEvents) (depositEvents []dex.DepositEvent, err error) {
+	for _, attrs := range groupAttributes(events, dex.EventTypeDeposit) {
+		var event dex.DepositEvent
+		if event.Sender, err = attrs.accAddress(dex.AttributeKeySender); err != nil {
+			return nil, err
+		}
+		if event.Product, err = attrs.str(dex.AttributeKeyProduct); err != nil {
+			return nil, err
+		}
+		if event.Amount, err = attrs.decCoin(dex.AttributeKeyAmount); err != nil {
+			return nil, err
+		}
+		depositEvents = append(depositEvents, event)
+	}
+
+	return
+}
+
+func parseOrdersEvents(events sdk.StringEvents, action string) (ordersEvents []order.OrdersEvent, err error) {
+	for _, attrs := range groupMessageAttributes(events) {
+		if attrs.Value[order.AttributeKeyAction] != action {
+			continue
+		}
+		if _, ok := attrs.Value[order.AttributeKeyOrders]; !ok {
+			continue
+		}
+
+		var event order.OrdersEvent
+		if event.Sender, err = attrs.accAddress(order.AttributeKeySender); err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal([]byte(attrs.Value[order.AttributeKeyOrders]), &event.Results); err != nil {
+			return nil, attrs.errParse(order.AttributeKeyOrders, ErrUnmarshalJSON(err.Error()))
+		}
+		ordersEvents = append(ordersEvents, event)
+	}
+
+	return
+}
+
+// eventAttributes - structure of the attributes that belong to one occurrence of an event
+type eventAttributes struct {
+	Type  string
+	Value map[string]string
+}
+
+// groupAttributes splits the attributes of a type of events into occurrences. The events in the tx response are
+// flattened by type, so a repeated key means the beginning of the next occurrence
+func groupAttributes(events sdk.StringEvents, eventType string) (groups []eventAttributes) {
+	for _, event := range events {
+		if event.Type != eventType {
+			continue
+		}
+
+		var current eventAttributes
+		for _, attr := range event.Attributes {
+			if _, ok := current.Value[attr.Key]; ok || current.Value == nil {
+				if current.Value != nil {
+					groups = append(groups, current)
+				}
+				current = eventAttributes{Type: eventType, Value: make(map[string]string)}
+			}
+			current.Value[attr.Key] = attr.Value
+		}
+		if current.Value != nil {
+			groups = append(groups, current)
+		}
+	}
+
+	return
+}
+
+// groupMessageAttributes splits the attributes of the message events into the occurrences of msgs. The message
+// attributes of a msg are split into several events in the raw abci events of deliver tx, while flattened into one
+// event in the tx response. As the action attribute always comes first in the message attributes of a msg, an action
+// or a repeated key means the beginning of the next msg in both layouts
+func groupMessageAttributes(events sdk.StringEvents) (groups []eventAttributes) {
+	var current eventAttributes
+	for _, event := range events {
+		if event.Type != order.EventTypeMessage {
+			continue
+		}
+
+		for _, attr := range event.Attributes {
+			if _, ok := current.Value[attr.Key]; ok || current.Value == nil || attr.Key == order.AttributeKeyAction {
+				if current.Value != nil {
+					groups = append(groups, current)
+				}
+				current = eventAttributes{Type: order.EventTypeMessage, Value: make(map[string]string)}
+			}
+			current.Value[attr.Key] = attr.Value
+		}
+	}
+	if current.Value != nil {
+		groups = append(groups, current)
+	}
+
+	return
+}
+
+func (ea eventAttributes) errParse(key string, err error) error {
+	return fmt.Errorf("failed. parse attribute %s of event %s error: %s", key, ea.Type, err)
+}
+
+func (ea eventAttributes) str(key string) (string, error) {
+	value, ok := ea.Value[key]
+	if !ok {
+		return "", fmt.Errorf("failed. attribute %s is missing in event %s", key, ea.Type)
+	}
+
+	return value, nil
+}
+
+func (ea eventAttributes) accAddress(key string) (addr sdk.AccAddress, err error) {
+	value, err := ea.str(key)
+	if err != nil {
+		return
+	}
+
+	if addr, err = sdk.AccAddressFromBech32(value); err != nil {
+		return addr, ea.errParse(key, err)
+	}
+
+	return
+}
+
+func (ea eventAttributes) dec(key string) (dec sdk.Dec, err error) {
+	value, err := ea.str(key)
+	if err != nil {
+		return
+	}
+
+	if dec, err = sdk.NewDecFromStr(value); err != nil {
+		return dec, ea.errParse(key, err)
+	}
+
+	return
+}
+
+func (ea eventAttributes) decCoin(key string) (coin sdk.DecCoin, err error) {
+	value, err := ea.str(key)
+	if err != nil {
+		return
+	}
+
+	if coin, err = sdk.ParseDecCoin(value); err != nil {
+		return coin, ea.errParse(key, err)
+	}
+
+	return
+}
+
+func (ea eventAttributes) decCoins(key string) (coins sdk.DecCoins, err error) {
+	value, err := ea.str(key)
+	if err != nil {
+		return
+	}
+
+	if coins, err = sdk.ParseDecCoins(value); err != nil {
+		return coins, ea.errParse(key, err)
+	}
+
+	return
+}
+
+func (ea eventAttributes) time(key string) (t time.Time, err error) {
+	value, err := ea.str(key)
+	if err != nil {
+		return
+	}
+
+	if t, err = time.Parse(time.RFC3339Nano, value); err != nil {
+		return t, ea.errParse(key, err)
+	}
+
+	return
+}
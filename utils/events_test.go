@@ -0,0 +1,291 @@
+package utils
+
+import (
+	order "github.com/okex/okchain-go-sdk/module/order/types"
+	"github.com/okex/okchain-go-sdk/module/tendermint/types"
+	sdk "github.com/okex/okchain-go-sdk/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+const (
+	eventAddr  = "okchain1dcsxvxgj374dv3wt9szflf9nz6342juzzkjnlz"
+	eventAddr1 = "okchain1alq9na49n9yycysh889rl90g9nhe58lcv27tfj"
+)
+
+func TestParseEventsFromResponse(t *testing.T) {
+	orderResults := getRawStrSlice([]order.OrderResult{buildMockOrderRes("ID0000000000-1"),
+		buildMockOrderRes("ID0000000000-2")}, []order.OrderResult{buildMockOrderRes("ID0000000000-1")})
+	txResp := sdk.TxResponse{
+		Logs: sdk.ABCIMessageLogs{
+			{
+				Events: sdk.StringEvents{
+					{
+						Type: "message",
+						Attributes: []sdk.Attribute{
+							{Key: "action", Value: "new"},
+							{Key: "sender", Value: eventAddr},
+							{Key: "orders", Value: orderResults[0]},
+							{Key: "action", Value: "cancel"},
+							{Key: "sender", Value: eventAddr},
+							{Key: "orders", Value: orderResults[1]},
+						},
+					},
+					{
+						Type: "transfer",
+						Attributes: []sdk.Attribute{
+							{Key: "recipient", Value: eventAddr1},
+							{Key: "sender", Value: eventAddr},
+							{Key: "amount", Value: "1.024okt,2.048btc-000"},
+							{Key: "recipient", Value: eventAddr},
+							{Key: "sender", Value: eventAddr1},
+							{Key: "amount", Value: "10.24okt"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	events := GetEventsFromResponse(&txResp)
+	require.Equal(t, 2, len(events))
+
+	transferEvents, err := ParseTransferEvents(events)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(transferEvents))
+	require.Equal(t, eventAddr, transferEvents[0].Sender.String())
+	require.Equal(t, eventAddr1, transferEvents[0].Recipient.String())
+	require.Equal(t, 2, len(transferEvents[0].Amount))
+	require.Equal(t, sdk.MustNewDecFromStr("1.024"), transferEvents[0].Amount[1].Amount)
+	require.Equal(t, eventAddr1, transferEvents[1].Sender.String())
+	require.Equal(t, sdk.MustNewDecFromStr("10.24"), transferEvents[1].Amount[0].Amount)
+
+	newOrderEvents, err := ParseNewOrderEvents(events)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(newOrderEvents))
+	require.Equal(t, eventAddr, newOrderEvents[0].Sender.String())
+	require.Equal(t, 2, len(newOrderEvents[0].Results))
+	require.Equal(t, "ID0000000000-2", newOrderEvents[0].Results[1].OrderID)
+
+	cancelOrderEvents, err := ParseCancelOrderEvents(events)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(cancelOrderEvents))
+	require.Equal(t, "ID0000000000-1", cancelOrderEvents[0].Results[0].OrderID)
+
+	fillEvents, err := ParseFillEvents(events)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(fillEvents))
+
+	// the deprecated events field
+	txResp = sdk.TxResponse{Events: txResp.Logs[0].Events}
+	transferEvents, err = ParseTransferEvents(GetEventsFromResponse(&txResp))
+	require.NoError(t, err)
+	require.Equal(t, 2, len(transferEvents))
+
+	// bad attributes
+	_, err = ParseTransferEvents(sdk.StringEvents{
+		{
+			Type: "transfer",
+			Attributes: []sdk.Attribute{
+				{Key: "recipient", Value: eventAddr1},
+				{Key: "sender", Value: eventAddr},
+			},
+		},
+	})
+	require.Error(t, err)
+	_, err = ParseTransferEvents(sdk.StringEvents{
+		{
+			Type: "transfer",
+			Attributes: []sdk.Attribute{
+				{Key: "recipient", Value: eventAddr1[1:]},
+				{Key: "sender", Value: eventAddr},
+				{Key: "amount", Value: "1.024okt"},
+			},
+		},
+	})
+	require.Error(t, err)
+	_, err = ParseNewOrderEvents(sdk.StringEvents{
+		{
+			Type: "message",
+			Attributes: []sdk.Attribute{
+				{Key: "action", Value: "new"},
+				{Key: "sender", Value: eventAddr},
+				{Key: "orders", Value: "string that failed to unmarshal JSON"},
+			},
+		},
+	})
+	require.Error(t, err)
+}
+
+func TestParseEventsFromDeliverTx(t *testing.T) {
+	completionTime := time.Unix(1580000000, 0).UTC()
+	deliverTx := types.ResponseDeliverTx{
+		Events: []types.Event{
+			{
+				Type: "fill",
+				Attributes: []types.KVPair{
+					{Key: []byte("order_id"), Value: []byte("ID0000000000-1")},
+					{Key: []byte("product"), Value: []byte("btc-000_okt")},
+					{Key: []byte("side"), Value: []byte("BUY")},
+					{Key: []byte("price"), Value: []byte("10.24")},
+					{Key: []byte("quantity"), Value: []byte("1.024")},
+					{Key: []byte("fee"), Value: []byte("0.001024okt")},
+				},
+			},
+			{
+				Type: "delegate",
+				Attributes: []types.KVPair{
+					{Key: []byte("delegator"), Value: []byte(eventAddr)},
+					{Key: []byte("amount"), Value: []byte("10.24okt")},
+				},
+			},
+			{
+				Type: "unbond",
+				Attributes: []types.KVPair{
+					{Key: []byte("delegator"), Value: []byte(eventAddr)},
+					{Key: []byte("amount"), Value: []byte("1.024okt")},
+					{Key: []byte("completion_time"), Value: []byte(completionTime.Format(time.RFC3339Nano))},
+				},
+			},
+			{
+				Type: "list",
+				Attributes: []types.KVPair{
+					{Key: []byte("owner"), Value: []byte(eventAddr)},
+					{Key: []byte("list_asset"), Value: []byte("btc-000")},
+					{Key: []byte("quote_asset"), Value: []byte("okt")},
+					{Key: []byte("init_price"), Value: []byte("10.24")},
+				},
+			},
+			{
+				Type: "deposit",
+				Attributes: []types.KVPair{
+					{Key: []byte("sender"), Value: []byte(eventAddr1)},
+					{Key: []byte("product"), Value: []byte("btc-000_okt")},
+					{Key: []byte("amount"), Value: []byte("100okt")},
+				},
+			},
+		},
+	}
+
+	events := GetEventsFromDeliverTx(deliverTx)
+	require.Equal(t, 5, len(events))
+
+	fillEvents, err := ParseFillEvents(events)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(fillEvents))
+	require.Equal(t, "ID0000000000-1", fillEvents[0].OrderID)
+	require.Equal(t, "btc-000_okt", fillEvents[0].Product)
+	require.Equal(t, "BUY", fillEvents[0].Side)
+	require.Equal(t, sdk.MustNewDecFromStr("10.24"), fillEvents[0].Price)
+	require.Equal(t, sdk.MustNewDecFromStr("1.024"), fillEvents[0].Quantity)
+	require.Equal(t, sdk.MustNewDecFromStr("0.001024"), fillEvents[0].Fee[0].Amount)
+
+	delegateEvents, err := ParseDelegateEvents(events)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(delegateEvents))
+	require.Equal(t, eventAddr, delegateEvents[0].Delegator.String())
+	require.Equal(t, sdk.NewDecCoinFromDec("okt", sdk.MustNewDecFromStr("10.24")), delegateEvents[0].Amount)
+
+	unbondEvents, err := ParseUnbondEvents(events)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(unbondEvents))
+	require.Equal(t, completionTime, unbondEvents[0].CompletionTime)
+
+	listEvents, err := ParseListEvents(events)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(listEvents))
+	require.Equal(t, "btc-000", listEvents[0].BaseAsset)
+	require.Equal(t, "okt", listEvents[0].QuoteAsset)
+	require.Equal(t, sdk.MustNewDecFromStr("10.24"), listEvents[0].InitPrice)
+
+	depositEvents, err := ParseDepositEvents(events)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(depositEvents))
+	require.Equal(t, eventAddr1, depositEvents[0].Sender.String())
+	require.Equal(t, "btc-000_okt", depositEvents[0].Product)
+
+	// bad attributes
+	deliverTx.Events[0].Attributes[3].Value = []byte("10.24.1")
+	_, err = ParseFillEvents(GetEventsFromDeliverTx(deliverTx))
+	require.Error(t, err)
+	deliverTx.Events[2].Attributes[2].Value = []byte("not a time")
+	_, err = ParseUnbondEvents(GetEventsFromDeliverTx(deliverTx))
+	require.Error(t, err)
+}
+
+func TestParseOrdersEventsFromDeliverTx(t *testing.T) {
+	orderResults := getRawStrSlice([]order.OrderResult{buildMockOrderRes("ID0000000000-1"),
+		buildMockOrderRes("ID0000000000-2")}, []order.OrderResult{buildMockOrderRes("ID0000000000-3")})
+	// the message attributes of each msg are split into several events in the raw abci events
+	deliverTx := types.ResponseDeliverTx{
+		Events: []types.Event{
+			{
+				Type:       "message",
+				Attributes: []types.KVPair{{Key: []byte("action"), Value: []byte("new")}},
+			},
+			{
+				Type: "message",
+				Attributes: []types.KVPair{
+					{Key: []byte("module"), Value: []byte("order")},
+					{Key: []byte("sender"), Value: []byte(eventAddr)},
+				},
+			},
+			{
+				Type: "transfer",
+				Attributes: []types.KVPair{
+					{Key: []byte("recipient"), Value: []byte(eventAddr1)},
+					{Key: []byte("sender"), Value: []byte(eventAddr)},
+					{Key: []byte("amount"), Value: []byte("1.024okt")},
+				},
+			},
+			{
+				Type:       "message",
+				Attributes: []types.KVPair{{Key: []byte("orders"), Value: []byte(orderResults[0])}},
+			},
+			{
+				Type:       "message",
+				Attributes: []types.KVPair{{Key: []byte("action"), Value: []byte("cancel")}},
+			},
+			{
+				Type: "message",
+				Attributes: []types.KVPair{
+					{Key: []byte("module"), Value: []byte("order")},
+					{Key: []byte("sender"), Value: []byte(eventAddr1)},
+				},
+			},
+			{
+				Type:       "message",
+				Attributes: []types.KVPair{{Key: []byte("orders"), Value: []byte(orderResults[1])}},
+			},
+		},
+	}
+
+	events := GetEventsFromDeliverTx(deliverTx)
+	newOrderEvents, err := ParseNewOrderEvents(events)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(newOrderEvents))
+	require.Equal(t, eventAddr, newOrderEvents[0].Sender.String())
+	require.Equal(t, 2, len(newOrderEvents[0].Results))
+	require.Equal(t, "ID0000000000-2", newOrderEvents[0].Results[1].OrderID)
+
+	cancelOrderEvents, err := ParseCancelOrderEvents(events)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(cancelOrderEvents))
+	require.Equal(t, eventAddr1, cancelOrderEvents[0].Sender.String())
+	require.Equal(t, "ID0000000000-3", cancelOrderEvents[0].Results[0].OrderID)
+
+	// the message events of the end block are not merged into the last msg of the block
+	var blockResults types.BlockResults
+	blockResults.Results.DeliverTx = []types.ResponseDeliverTx{deliverTx, deliverTx}
+	blockResults.Results.EndBlock.Events = []types.Event{
+		{
+			Type:       "message",
+			Attributes: []types.KVPair{{Key: []byte("sender"), Value: []byte(eventAddr)}},
+		},
+	}
+	cancelOrderEvents, err = ParseCancelOrderEvents(GetEventsFromBlockResults(blockResults))
+	require.NoError(t, err)
+	require.Equal(t, 2, len(cancelOrderEvents))
+	require.Equal(t, eventAddr1, cancelOrderEvents[1].Sender.String())
+}
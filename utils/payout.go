@@ -0,0 +1,94 @@
+package utils
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/okex/okchain-go-sdk/module/token/types"
+)
+
+// ParsePayoutsCSV parses the receivers of a batch payout from csv with the columns of address and amount. The header
+// row is optional and the lines starting with '#' are ignored
+// Example:
+// `address,amount
+// addr1,1okt
+// addr2,"1okt,2btc-000"`
+func ParsePayoutsCSV(r io.Reader) ([]types.PayoutRecord, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var records []types.PayoutRecord
+	for isFirst := true; ; isFirst = false {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed. read csv error: %s", err)
+		}
+
+		// the physical line of the record in the csv, counting the comments and the blank lines
+		line, _ := reader.FieldPos(0)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("failed. line %d: a payout should be with 2 fields but got %d", line, len(fields))
+		}
+
+		toAddrStr, amountStr := strings.TrimSpace(fields[0]), strings.TrimSpace(fields[1])
+		if isFirst && isPayoutsHeader(toAddrStr) {
+			continue
+		}
+
+		record := types.NewPayoutRecord(toAddrStr, amountStr)
+		if _, err := record.ToTransferUnit(); err != nil {
+			return nil, fmt.Errorf("failed. line %d: %s", line, err)
+		}
+		records = append(records, record)
+	}
+
+	if len(records) == 0 {
+		return nil, errors.New("failed. no payout in csv")
+	}
+
+	return records, nil
+}
+
+// ParsePayoutsJSON parses the receivers of a batch payout from a json array. The output of WritePayoutsJSON is accepted
+// as well to resume a batch payout
+// Example:
+// `[{"to":"addr1","amount":"1okt"},{"to":"addr2","amount":"1okt,2btc-000"}]`
+func ParsePayoutsJSON(r io.Reader) ([]types.PayoutRecord, error) {
+	var records []types.PayoutRecord
+	if err := json.NewDecoder(r).Decode(&records); err != nil {
+		return nil, fmt.Errorf("failed. decode json error: %s", err)
+	}
+
+	if len(records) == 0 {
+		return nil, errors.New("failed. no payout in json")
+	}
+
+	for i, record := range records {
+		if _, err := record.ToTransferUnit(); err != nil {
+			return nil, fmt.Errorf("failed. payout %d: %s", i, err)
+		}
+	}
+
+	return records, nil
+}
+
+// WritePayoutsJSON writes the payout records with their results as a json array
+func WritePayoutsJSON(w io.Writer, records []types.PayoutRecord) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(records)
+}
+
+func isPayoutsHeader(field string) bool {
+	field = strings.ToLower(field)
+	return field == "address" || field == "to"
+}
@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/okex/okchain-go-sdk/module/token/types"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	payoutAddr1 = "okchain1dcsxvxgj374dv3wt9szflf9nz6342juzzkjnlz"
+	payoutAddr2 = "okchain1wux20ku36ntgtxpgm7my9863xy3fqs0xgh66d7"
+)
+
+func TestParsePayoutsCSV(t *testing.T) {
+	csvStr := `address,amount
+# comment line
+` + payoutAddr1 + `,1.024okt
+` + payoutAddr2 + `, "1okt,2btc-000"
+`
+	records, err := ParsePayoutsCSV(strings.NewReader(csvStr))
+	require.NoError(t, err)
+	require.Equal(t, 2, len(records))
+	require.Equal(t, types.NewPayoutRecord(payoutAddr1, "1.024okt"), records[0])
+	require.Equal(t, types.NewPayoutRecord(payoutAddr2, "1okt,2btc-000"), records[1])
+
+	// without header
+	records, err = ParsePayoutsCSV(strings.NewReader(payoutAddr1 + ",1okt"))
+	require.NoError(t, err)
+	require.Equal(t, 1, len(records))
+
+	// invalid address
+	_, err = ParsePayoutsCSV(strings.NewReader(payoutAddr1 + ",1okt\n" + payoutAddr2[1:] + ",1okt"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "line 2")
+
+	// the comments and the blank lines count in the line number
+	_, err = ParsePayoutsCSV(strings.NewReader("address,amount\n# comment line\n\n" + payoutAddr1 + ",1okt\n" +
+		payoutAddr2[1:] + ",1okt"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "line 5")
+
+	// invalid amount
+	_, err = ParsePayoutsCSV(strings.NewReader(payoutAddr1 + ",-1okt"))
+	require.Error(t, err)
+	_, err = ParsePayoutsCSV(strings.NewReader(payoutAddr1 + ",0okt"))
+	require.Error(t, err)
+
+	// invalid fields
+	_, err = ParsePayoutsCSV(strings.NewReader(payoutAddr1 + ",1okt,memo"))
+	require.Error(t, err)
+
+	// empty
+	_, err = ParsePayoutsCSV(strings.NewReader("address,amount\n"))
+	require.Error(t, err)
+}
+
+func TestParsePayoutsJSON(t *testing.T) {
+	records := []types.PayoutRecord{
+		types.NewPayoutRecord(payoutAddr1, "1.024okt"),
+		types.NewPayoutRecord(payoutAddr2, "1okt,2btc-000"),
+	}
+	records[0].Status, records[0].TxHash = types.PayoutStatusSucceeded, "tx hash"
+	records[1].Status, records[1].Error = types.PayoutStatusFailed, "insufficient fee"
+
+	// round trip for resuming
+	var buf bytes.Buffer
+	require.NoError(t, WritePayoutsJSON(&buf, records))
+	readRecords, err := ParsePayoutsJSON(&buf)
+	require.NoError(t, err)
+	require.Equal(t, records, readRecords)
+
+	readRecords, err = ParsePayoutsJSON(strings.NewReader(`[{"to":"` + payoutAddr1 + `","amount":"1okt"}]`))
+	require.NoError(t, err)
+	require.Equal(t, []types.PayoutRecord{types.NewPayoutRecord(payoutAddr1, "1okt")}, readRecords)
+
+	_, err = ParsePayoutsJSON(strings.NewReader(`[{"to":"` + payoutAddr1 + `","amount":"okt"}]`))
+	require.Error(t, err)
+
+	_, err = ParsePayoutsJSON(strings.NewReader(`[]`))
+	require.Error(t, err)
+
+	_, err = ParsePayoutsJSON(strings.NewReader(`{`))
+	require.Error(t, err)
+}
@@ -13,15 +13,18 @@ import (
 // ParseBlock converts raw tendermint block type to the one gosdk requires
 func ParseBlock(cdc sdk.SDKCodec, pTmBlock *tmtypes.Block) (block types.Block, err error) {
 	var stdTxs []sdk.StdTx
+	var txHashes []common.HexBytes
 	for _, txBytes := range pTmBlock.Txs {
 		var stdTx sdk.StdTx
 		if err = cdc.UnmarshalBinaryLengthPrefixed(txBytes, &stdTx); err != nil {
 			return block, fmt.Errorf("failed. unmarshal tx info from tendermint block query error: %s", err)
 		}
 		stdTxs = append(stdTxs, stdTx)
+		txHashes = append(txHashes, txBytes.Hash())
 	}
 
-	return types.NewBlock(pTmBlock.Header, types.NewData(stdTxs), pTmBlock.Evidence, *pTmBlock.LastCommit), err
+	return types.NewBlock(pTmBlock.Header, types.NewDataWithHashes(stdTxs, txHashes), pTmBlock.Evidence, *pTmBlock.LastCommit),
+		err
 }
 
 // ParseBlockResults converts raw tendermint block result type to the one gosdk requires
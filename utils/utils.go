@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"bytes"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -21,6 +22,42 @@ func GetStdTxFromFile(codec sdk.SDKCodec, filePath string) (stdTx sdk.StdTx, err
 	return
 }
 
+// GetStdTxFromJSON gets the instance of stdTx from json bytes, such as the ones passed between parties over queues
+func GetStdTxFromJSON(codec sdk.SDKCodec, jsonBytes []byte) (stdTx sdk.StdTx, err error) {
+	if err = codec.UnmarshalJSON(jsonBytes, &stdTx); err != nil {
+		return stdTx, fmt.Errorf("failed. unmarshal stdTx error: %s", err)
+	}
+
+	return
+}
+
+// GetTransferOwnershipMsg gets the only msg of a transfer-ownership tx of token or dex module
+func GetTransferOwnershipMsg(stdTx sdk.StdTx) (sdk.Msg, error) {
+	if len(stdTx.Msgs) != 1 {
+		return nil, errors.New("failed. a transfer-ownership tx should contain exactly one msg")
+	}
+
+	return stdTx.Msgs[0], nil
+}
+
+// CheckToSignature verifies that the receiver's signature in a transfer-ownership msg is signed by the receiver over
+// the sign bytes of the msg without the signature
+func CheckToSignature(toAddr sdk.AccAddress, toSig sdk.StdSignature, unsignedBytes []byte) error {
+	if toSig.PubKey == nil || len(toSig.Signature) == 0 {
+		return errors.New("failed. the transfer-ownership tx hasn't been signed by the receiver")
+	}
+
+	if !bytes.Equal(toSig.PubKey.Address(), toAddr) {
+		return errors.New("failed. the signature of the receiver is signed by others")
+	}
+
+	if !toSig.PubKey.VerifyBytes(unsignedBytes, toSig.Signature) {
+		return errors.New("failed. invalid signature of the receiver")
+	}
+
+	return nil
+}
+
 // ParseValAddresses parses validator address string to types.ValAddress
 func ParseValAddresses(valAddrsStr []string) ([]sdk.ValAddress, error) {
 	valLen := len(valAddrsStr)
@@ -3,6 +3,7 @@ package utils
 import (
 	sdk "github.com/okex/okchain-go-sdk/types"
 	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
 	"testing"
 )
 
@@ -25,3 +26,19 @@ func TestParseValAddresses(t *testing.T) {
 	_, err = ParseValAddresses(valAddrsStr)
 	require.Error(t, err)
 }
+
+func TestCheckToSignature(t *testing.T) {
+	privKey, otherPrivKey := secp256k1.GenPrivKey(), secp256k1.GenPrivKey()
+	toAddr := sdk.AccAddress(privKey.PubKey().Address())
+	unsignedBytes := []byte("unsigned msg")
+	signature, err := privKey.Sign(unsignedBytes)
+	require.NoError(t, err)
+
+	require.NoError(t, CheckToSignature(toAddr, sdk.NewStdSignature(privKey.PubKey(), signature), unsignedBytes))
+	require.Error(t, CheckToSignature(toAddr, sdk.StdSignature{}, unsignedBytes))
+	require.Error(t, CheckToSignature(toAddr, sdk.NewStdSignature(otherPrivKey.PubKey(), signature), unsignedBytes))
+	require.Error(t, CheckToSignature(toAddr, sdk.NewStdSignature(privKey.PubKey(), signature), []byte("other msg")))
+
+	_, err = GetTransferOwnershipMsg(sdk.StdTx{})
+	require.Error(t, err)
+}